@@ -24,3 +24,72 @@ http://localhost:8080/
 
 Note: BOM analysis (components and then graph) may takes a while for large sboms.
 
+
+# Setup users
+* Set the admin password and create the `Scribe_backend` team with its permissions and api key.
+Running it again converges the server without changing anything already in place.
+```
+DEPEND_TRACK_PASS=<admin password> make setup-users
+```
+
+* The command waits up to `-wait` for the api server to come up.
+
+* The team api key export is written to `scribe_backend_api_key` when the key is generated,
+newer servers only show a key once so a rerun keeps the existing file.
+
+# Server state as code
+* Describe teams, policies, notification rules, repositories and config properties in a YAML spec,
+see `test/integration/test-fixtures/reconcile/spec.yaml`.
+
+* Print the plan, the server is only read.
+```
+API_KEY=<api key> go run . reconcile -spec spec.yaml
+```
+
+* Apply the plan. Team api keys generated by the apply are written to `-api-key-file`, one tab separated
+team name and key per line, as the server only shows a key when it is generated.
+```
+API_KEY=<api key> go run . reconcile -spec spec.yaml -apply -api-key-file api_keys.tsv
+```
+
+* Nightly drift detection, exits with status 2 if the server does not match the spec.
+```
+API_KEY=<api key> go run . reconcile -spec spec.yaml -detect-drift
+```
+
+# Outdated dependencies
+* Report the sbom components behind their latest version, grouped by ecosystem and upgrade class.
+Formats are `table`, `json`, `csv` and `markdown`.
+The libyears column is the time between the current and latest release, it is empty unless the server
+reports both release dates, the current one is read from the integrity metadata of servers from 4.11.
+The client `GetPurlVersionsBySbom` keys the versions by purl, `GetLatestVersionBySbom` and
+`GetVulnraibilityListBySbom` stay keyed by component name.
+```
+API_KEY=<api key> go run . outdated -sbom sbom.json -format markdown
+```
+
+# Vulnerability blast radius
+* List every project and component affected by a vulnerability with its analysis state.
+```
+API_KEY=<api key> go run . blast-radius -source NVD -id CVE-2021-44228
+```
+
+# Internal advisories
+* Import a directory of OSV advisories as INTERNAL vulnerabilities, advisories are matched by id
+and withdrawn ones are deleted, see `test/integration/test-fixtures/osv`. Advisories with the modified time
+of the server vulnerability are skipped, as are affected packages of an ecosystem without a purl type.
+```
+API_KEY=<api key> go run . import-osv -dir advisories -dry-run
+API_KEY=<api key> go run . import-osv -dir advisories
+```
+
+# License compliance
+* Classify the sbom component licenses by the server license groups, components without a license are reported as missing.
+Invalid license expressions are reported as unknown, the json report holds their parse error.
+```
+API_KEY=<api key> go run . licenses -sbom sbom.json -allow Permissive -deny Copyleft -fail
+```
+
+# Client API changes
+* `Project.Active` is a `*bool`, nil keeps the server default so `UpdateProject` can deactivate a project
+with `Active: false`. Read it with `Project.IsActive()`.
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	ApiTeam                   = "/team"
+	ApiTeamKey                = "/team/key"
+	ApiPermission             = "/permission"
+	ApiForceChangePassword    = "/user/forceChangePassword"
+	FormUrlEncodedContentType = "application/x-www-form-urlencoded"
+)
+
+// Permissions as used by dependency track
+const (
+	PermissionBomUpload               = "BOM_UPLOAD"
+	PermissionProjectCreationUpload   = "PROJECT_CREATION_UPLOAD"
+	PermissionViewPortfolio           = "VIEW_PORTFOLIO"
+	PermissionAccessManagement        = "ACCESS_MANAGEMENT"
+	PermissionPolicyManagement        = "POLICY_MANAGEMENT"
+	PermissionPolicyViolationAnalysis = "POLICY_VIOLATION_ANALYSIS"
+	PermissionPortfolioManagement     = "PORTFOLIO_MANAGEMENT"
+	PermissionSystemConfiguration     = "SYSTEM_CONFIGURATION"
+	PermissionVulnerabilityAnalysis   = "VULNERABILITY_ANALYSIS"
+	PermissionViewVulnerability       = "VIEW_VULNERABILITY"
+	PermissionViewPolicyViolation     = "VIEW_POLICY_VIOLATION"
+)
+
+type ApiKey struct {
+	Key string `json:"key"`
+}
+
+type Team struct {
+	UUID        string                  `json:"uuid,omitempty"`
+	Name        string                  `json:"name"`
+	ApiKeys     []ApiKey                `json:"apiKeys,omitempty"`
+	Permissions DepTrackPermissionsList `json:"permissions,omitempty"`
+}
+
+type TeamList []Team
+
+func (team *Team) HasPermission(name string) bool {
+	for _, permission := range team.Permissions {
+		if permission.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (team_list TeamList) FindByName(name string) *Team {
+	for i := range team_list {
+		if team_list[i].Name == name {
+			return &team_list[i]
+		}
+	}
+	return nil
+}
+
+func (depClient *DepTrackClient) GetTeamsContext(ctx context.Context) (TeamList, error) {
+	var team_list TeamList
+	if err := depClient.GetJsonContext(ctx, ApiTeam, &team_list); err != nil {
+		return nil, err
+	}
+	return team_list, nil
+}
+
+func (depClient *DepTrackClient) GetTeams() (TeamList, error) {
+	return depClient.GetTeamsContext(context.Background())
+}
+
+func (depClient *DepTrackClient) CreateTeamContext(ctx context.Context, team *DepTrackTeamPut) (*Team, error) {
+	var created Team
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiTeam, team, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreateTeam(team *DepTrackTeamPut) (*Team, error) {
+	return depClient.CreateTeamContext(context.Background(), team)
+}
+
+func (depClient *DepTrackClient) DeleteTeamContext(ctx context.Context, uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, ApiTeam, Team{UUID: uuid}, nil)
+}
+
+func (depClient *DepTrackClient) DeleteTeam(uuid string) error {
+	return depClient.DeleteTeamContext(context.Background(), uuid)
+}
+
+func (depClient *DepTrackClient) GetPermissionsContext(ctx context.Context) (DepTrackPermissionsList, error) {
+	var permission_list DepTrackPermissionsList
+	if err := depClient.GetJsonContext(ctx, ApiPermission, &permission_list); err != nil {
+		return nil, err
+	}
+	return permission_list, nil
+}
+
+func (depClient *DepTrackClient) GetPermissions() (DepTrackPermissionsList, error) {
+	return depClient.GetPermissionsContext(context.Background())
+}
+
+func teamPermissionApi(permission string, team_uuid string) string {
+	return ApiPermission + "/" + permission + "/team/" + team_uuid
+}
+
+func (depClient *DepTrackClient) AddTeamPermissionContext(ctx context.Context, team_uuid string, permission string) (*Team, error) {
+	var team Team
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, teamPermissionApi(permission, team_uuid), nil, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (depClient *DepTrackClient) AddTeamPermission(team_uuid string, permission string) (*Team, error) {
+	return depClient.AddTeamPermissionContext(context.Background(), team_uuid, permission)
+}
+
+func (depClient *DepTrackClient) RemoveTeamPermissionContext(ctx context.Context, team_uuid string, permission string) (*Team, error) {
+	var team Team
+	if err := depClient.SendJsonContext(ctx, http.MethodDelete, teamPermissionApi(permission, team_uuid), nil, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (depClient *DepTrackClient) RemoveTeamPermission(team_uuid string, permission string) (*Team, error) {
+	return depClient.RemoveTeamPermissionContext(context.Background(), team_uuid, permission)
+}
+
+func (depClient *DepTrackClient) GenerateApiKeyContext(ctx context.Context, team_uuid string) (*ApiKey, error) {
+	var api_key ApiKey
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiTeam+"/"+team_uuid+"/key", nil, &api_key); err != nil {
+		return nil, err
+	}
+	return &api_key, nil
+}
+
+func (depClient *DepTrackClient) GenerateApiKey(team_uuid string) (*ApiKey, error) {
+	return depClient.GenerateApiKeyContext(context.Background(), team_uuid)
+}
+
+// RegenerateApiKeyContext replaces the key with a new one, the old key stops working.
+func (depClient *DepTrackClient) RegenerateApiKeyContext(ctx context.Context, key string) (*ApiKey, error) {
+	var api_key ApiKey
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiTeamKey+"/"+key, nil, &api_key); err != nil {
+		return nil, err
+	}
+	return &api_key, nil
+}
+
+func (depClient *DepTrackClient) RegenerateApiKey(key string) (*ApiKey, error) {
+	return depClient.RegenerateApiKeyContext(context.Background(), key)
+}
+
+func (depClient *DepTrackClient) RevokeApiKeyContext(ctx context.Context, key string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, ApiTeamKey+"/"+key, nil, nil)
+}
+
+func (depClient *DepTrackClient) RevokeApiKey(key string) error {
+	return depClient.RevokeApiKeyContext(context.Background(), key)
+}
+
+// ForceChangePasswordContext changes the password of a user that must change it before the first login.
+func (depClient *DepTrackClient) ForceChangePasswordContext(ctx context.Context, username string, password string, new_password string) error {
+	values := url.Values{
+		"username":        {username},
+		"password":        {password},
+		"newPassword":     {new_password},
+		"confirmPassword": {new_password},
+	}
+
+	resp, err := depClient.PostContext(ctx, ApiForceChangePassword, FormUrlEncodedContentType, strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (depClient *DepTrackClient) ForceChangePassword(username string, password string, new_password string) error {
+	return depClient.ForceChangePasswordContext(context.Background(), username, password, new_password)
+}
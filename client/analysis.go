@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+const ApiAnalysis = "/analysis"
+
+type AnalysisState string
+type AnalysisJustification string
+type AnalysisResponse string
+
+const (
+	AnalysisStateExploitable   AnalysisState = "EXPLOITABLE"
+	AnalysisStateInTriage      AnalysisState = "IN_TRIAGE"
+	AnalysisStateFalsePositive AnalysisState = "FALSE_POSITIVE"
+	AnalysisStateNotAffected   AnalysisState = "NOT_AFFECTED"
+	AnalysisStateResolved      AnalysisState = "RESOLVED"
+	AnalysisStateNotSet        AnalysisState = "NOT_SET"
+)
+
+const (
+	JustificationCodeNotPresent               AnalysisJustification = "CODE_NOT_PRESENT"
+	JustificationCodeNotReachable             AnalysisJustification = "CODE_NOT_REACHABLE"
+	JustificationRequiresConfiguration        AnalysisJustification = "REQUIRES_CONFIGURATION"
+	JustificationRequiresDependency           AnalysisJustification = "REQUIRES_DEPENDENCY"
+	JustificationRequiresEnvironment          AnalysisJustification = "REQUIRES_ENVIRONMENT"
+	JustificationProtectedByCompiler          AnalysisJustification = "PROTECTED_BY_COMPILER"
+	JustificationProtectedAtRuntime           AnalysisJustification = "PROTECTED_AT_RUNTIME"
+	JustificationProtectedAtPerimeter         AnalysisJustification = "PROTECTED_AT_PERIMETER"
+	JustificationProtectedByMitigatingControl AnalysisJustification = "PROTECTED_BY_MITIGATING_CONTROL"
+	JustificationNotSet                       AnalysisJustification = "NOT_SET"
+)
+
+const (
+	ResponseCanNotFix           AnalysisResponse = "CAN_NOT_FIX"
+	ResponseWillNotFix          AnalysisResponse = "WILL_NOT_FIX"
+	ResponseUpdate              AnalysisResponse = "UPDATE"
+	ResponseRollback            AnalysisResponse = "ROLLBACK"
+	ResponseWorkaroundAvailable AnalysisResponse = "WORKAROUND_AVAILABLE"
+	ResponseNotSet              AnalysisResponse = "NOT_SET"
+)
+
+type AnalysisComment struct {
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	Commenter string `json:"commenter,omitempty"`
+}
+
+type Analysis struct {
+	State         AnalysisState         `json:"analysisState,omitempty"`
+	Justification AnalysisJustification `json:"analysisJustification,omitempty"`
+	Response      AnalysisResponse      `json:"analysisResponse,omitempty"`
+	Details       string                `json:"analysisDetails,omitempty"`
+	Comments      []AnalysisComment     `json:"analysisComments,omitempty"`
+	IsSuppressed  bool                  `json:"isSuppressed,omitempty"`
+}
+
+// AnalysisTarget identifies a project component and vulnerability pair by uuid.
+type AnalysisTarget struct {
+	Project       string `json:"project"`
+	Component     string `json:"component"`
+	Vulnerability string `json:"vulnerability"`
+}
+
+// AnalysisDecision is recorded on an AnalysisTarget, empty fields are left unchanged by the server.
+type AnalysisDecision struct {
+	State         AnalysisState         `json:"analysisState,omitempty"`
+	Justification AnalysisJustification `json:"analysisJustification,omitempty"`
+	Response      AnalysisResponse      `json:"analysisResponse,omitempty"`
+	Details       string                `json:"analysisDetails,omitempty"`
+	Comment       string                `json:"comment,omitempty"`
+	Suppressed    *bool                 `json:"isSuppressed,omitempty"`
+}
+
+type analysisRequest struct {
+	AnalysisTarget
+	AnalysisDecision
+}
+
+func (depClient *DepTrackClient) GetAnalysisContext(ctx context.Context, target AnalysisTarget) (*Analysis, error) {
+	var analysis Analysis
+	if err := depClient.GetJsonWithParamsContext(ctx, ApiAnalysis, target, &analysis); err != nil {
+		return nil, err
+	}
+	return &analysis, nil
+}
+
+func (depClient *DepTrackClient) GetAnalysis(target AnalysisTarget) (*Analysis, error) {
+	return depClient.GetAnalysisContext(context.Background(), target)
+}
+
+func (depClient *DepTrackClient) RecordAnalysisContext(ctx context.Context, target AnalysisTarget, decision AnalysisDecision) (*Analysis, error) {
+	var analysis Analysis
+	request := analysisRequest{AnalysisTarget: target, AnalysisDecision: decision}
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiAnalysis, request, &analysis); err != nil {
+		return nil, err
+	}
+	return &analysis, nil
+}
+
+func (depClient *DepTrackClient) RecordAnalysis(target AnalysisTarget, decision AnalysisDecision) (*Analysis, error) {
+	return depClient.RecordAnalysisContext(context.Background(), target, decision)
+}
+
+func (depClient *DepTrackClient) SetSuppressionContext(ctx context.Context, target AnalysisTarget, suppressed bool, comment string) (*Analysis, error) {
+	return depClient.RecordAnalysisContext(ctx, target, AnalysisDecision{Suppressed: &suppressed, Comment: comment})
+}
+
+func (depClient *DepTrackClient) SetSuppression(target AnalysisTarget, suppressed bool, comment string) (*Analysis, error) {
+	return depClient.SetSuppressionContext(context.Background(), target, suppressed, comment)
+}
+
+// RecordAnalysisByPurlContext records the decision in every project containing a component with the purl
+// that is affected by the vulnerability, the targets the decision was recorded on are returned.
+func (depClient *DepTrackClient) RecordAnalysisByPurlContext(ctx context.Context, purl string, source string, vuln_id string, decision AnalysisDecision) ([]AnalysisTarget, error) {
+	component_list, err := depClient.GetAllComponentsIdentityContext(ctx, GetComponentsIdentityParams{Purl: purl})
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []AnalysisTarget
+	for _, component := range component_list {
+		if component.Project == nil {
+			continue
+		}
+
+		vulnraibility_list, err := depClient.GetAllVulnerabilityComponenetByUUIDContext(ctx, component.UUID, true)
+		if err != nil {
+			return targets, err
+		}
+
+		for _, vulnraibility := range vulnraibility_list {
+			if vulnraibility.VulnId != vuln_id || vulnraibility.Source != source {
+				continue
+			}
+
+			target := AnalysisTarget{Project: component.Project.UUID, Component: component.UUID, Vulnerability: vulnraibility.UUID}
+			if _, err := depClient.RecordAnalysisContext(ctx, target, decision); err != nil {
+				return targets, err
+			}
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+func (depClient *DepTrackClient) RecordAnalysisByPurl(purl string, source string, vuln_id string, decision AnalysisDecision) ([]AnalysisTarget, error) {
+	return depClient.RecordAnalysisByPurlContext(context.Background(), purl, source, vuln_id, decision)
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"errors"
+)
+
+// BlastRadiusEntry is an affected component of a project.
+type BlastRadiusEntry struct {
+	Project        string        `json:"project"`
+	ProjectName    string        `json:"projectName"`
+	ProjectVersion string        `json:"projectVersion,omitempty"`
+	Active         bool          `json:"active"`
+	Component      string        `json:"component"`
+	Purl           string        `json:"purl,omitempty"`
+	State          AnalysisState `json:"analysisState"`
+	Suppressed     bool          `json:"suppressed"`
+}
+
+// BlastRadius lists every project component affected by a vulnerability.
+type BlastRadius struct {
+	Vulnerability *Vulnraibility     `json:"vulnerability"`
+	Projects      int                `json:"projects"`
+	Entries       []BlastRadiusEntry `json:"entries"`
+}
+
+// BlastRadiusContext fans out over the projects affected by the vulnerability and lists their affected components.
+// On project errors the entries of the other projects are returned along with a *WalkError.
+func (depClient *DepTrackClient) BlastRadiusContext(ctx context.Context, source string, vuln_id string, opts WalkOptions) (*BlastRadius, error) {
+	vulnraibility, err := depClient.GetVulnerabilityContext(ctx, source, vuln_id)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := depClient.GetAffectedProjectsContext(ctx, source, vuln_id)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := depClient.affectedFindingsContext(ctx, source, vuln_id, projects, opts)
+	var walk_err *WalkError
+	if err != nil && !errors.As(err, &walk_err) {
+		return nil, err
+	}
+
+	blast_radius := &BlastRadius{Vulnerability: vulnraibility, Projects: len(projects)}
+	for index, project := range projects {
+		for _, finding := range findings[index] {
+			state := AnalysisState(finding.Analysis.State)
+			if state == "" {
+				state = AnalysisStateNotSet
+			}
+			blast_radius.Entries = append(blast_radius.Entries, BlastRadiusEntry{
+				Project:        project.UUID,
+				ProjectName:    project.Name,
+				ProjectVersion: project.Version,
+				Active:         project.Active,
+				Component:      finding.Component.UUID,
+				Purl:           finding.Component.Purl,
+				State:          state,
+				Suppressed:     finding.Analysis.IsSuppressed,
+			})
+		}
+	}
+	return blast_radius, err
+}
+
+func (depClient *DepTrackClient) BlastRadius(source string, vuln_id string) (*BlastRadius, error) {
+	return depClient.BlastRadiusContext(context.Background(), source, vuln_id, DefaultWalkOptions)
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type Capability string
+
+const (
+	CapabilityVex               Capability = "vex"
+	CapabilityProjectHierarchy  Capability = "project hierarchy"
+	CapabilityEventToken        Capability = "event token"
+	CapabilityIntegrityMetadata Capability = "integrity metadata"
+)
+
+// Capabilities maps version gated features to the first server version supporting them.
+var Capabilities = map[Capability]string{
+	CapabilityVex:               "4.5.0",
+	CapabilityProjectHierarchy:  "4.7.0",
+	CapabilityEventToken:        EventTokenVersion,
+	CapabilityIntegrityMetadata: "4.11.0",
+}
+
+// ErrUnsupportedByServer matches any UnsupportedError using errors.Is.
+var ErrUnsupportedByServer = errors.New("unsupported by server")
+
+// UnsupportedError is returned instead of calling an endpoint the server version does not serve.
+type UnsupportedError struct {
+	Capability    Capability
+	Required      string
+	ServerVersion string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s %s, Required: %s Server: %s", e.Capability, ErrUnsupportedByServer, e.Required, e.ServerVersion)
+}
+
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupportedByServer
+}
+
+// SupportsContext reports whether the server supports capability.
+// Unknown capabilities and servers whose version can not be detected are assumed to support it.
+func (depClient *DepTrackClient) SupportsContext(ctx context.Context, capability Capability) bool {
+	return depClient.requireContext(ctx, capability) == nil
+}
+
+func (depClient *DepTrackClient) Supports(capability Capability) bool {
+	return depClient.SupportsContext(context.Background(), capability)
+}
+
+// requireContext returns an UnsupportedError if the server is older than the capability version.
+func (depClient *DepTrackClient) requireContext(ctx context.Context, capability Capability) error {
+	required, ok := Capabilities[capability]
+	if !ok {
+		return nil
+	}
+
+	info, err := depClient.ServerInfoContext(ctx)
+	if err != nil {
+		log.Debugf("Server version detection failed, assuming %s is supported, Err: %+v", capability, err)
+		return nil
+	}
+	if _, ok := parseVersion(info.Version); !ok || info.AtLeast(required) {
+		return nil
+	}
+	return &UnsupportedError{Capability: capability, Required: required, ServerVersion: info.Version}
+}
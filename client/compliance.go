@@ -0,0 +1,336 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+type LicenseStatus string
+
+const (
+	LicenseAllowed LicenseStatus = "allowed"
+	LicenseDenied  LicenseStatus = "denied"
+	LicenseUnknown LicenseStatus = "unknown"
+	// LicenseMissing is reported for components without any license
+	LicenseMissing LicenseStatus = "missing"
+)
+
+// ComplianceConfig names the license groups of allowed and denied licenses, a license in both is denied.
+type ComplianceConfig struct {
+	Allowed []string `json:"allowed" yaml:"allowed"`
+	Denied  []string `json:"denied" yaml:"denied"`
+}
+
+type ComponentCompliance struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Purl    string `json:"purl,omitempty"`
+	// Licenses is the evaluated license expression, the sbom licenses of a component are joined with AND
+	Licenses string        `json:"licenses,omitempty"`
+	Status   LicenseStatus `json:"status"`
+	Groups   []string      `json:"groups,omitempty"`
+	// Error is the parse error of an invalid license expression, kept as is in Licenses and evaluated as unknown
+	Error string `json:"error,omitempty"`
+}
+
+type ComplianceReport struct {
+	Components []ComponentCompliance `json:"components"`
+	Counts     map[LicenseStatus]int `json:"counts"`
+}
+
+// Compliant reports whether no component has a denied license.
+func (report *ComplianceReport) Compliant() bool {
+	return report.Counts[LicenseDenied] == 0
+}
+
+type licensePolicy struct {
+	status LicenseStatus
+	groups []string
+}
+
+// licenseIndex maps lower cased license ids and names to their status.
+type licenseIndex map[string]*licensePolicy
+
+func newLicenseIndex(groups LicenseGroupList, config ComplianceConfig) (licenseIndex, error) {
+	index := make(licenseIndex)
+	add := func(group_names []string, status LicenseStatus) error {
+		for _, group_name := range group_names {
+			group := groups.FindByName(group_name)
+			if group == nil {
+				return fmt.Errorf("license group %q not found", group_name)
+			}
+			for _, license := range group.Licenses {
+				for _, key := range []string{license.LicenseId, license.Name} {
+					if key == "" {
+						continue
+					}
+					key = strings.ToLower(key)
+					policy, ok := index[key]
+					if !ok {
+						policy = &licensePolicy{status: status}
+						index[key] = policy
+					}
+					if status == LicenseDenied {
+						policy.status = LicenseDenied
+					}
+					if !containsString(policy.groups, group.Name) {
+						policy.groups = append(policy.groups, group.Name)
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := add(config.Allowed, LicenseAllowed); err != nil {
+		return nil, err
+	}
+	if err := add(config.Denied, LicenseDenied); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// lookup matches a license id, ids with the or later suffix fall back to the base license.
+func (index licenseIndex) lookup(license_id string) *licensePolicy {
+	key := strings.ToLower(license_id)
+	if policy, ok := index[key]; ok {
+		return policy
+	}
+	return index[strings.TrimSuffix(key, "+")]
+}
+
+var statusRank = map[LicenseStatus]int{
+	LicenseAllowed: 0,
+	LicenseUnknown: 1,
+	LicenseDenied:  2,
+}
+
+// licenseExpression evaluates SPDX license expressions, AND takes the worst status of its operands and OR the best.
+type licenseExpression struct {
+	tokens []string
+	pos    int
+	index  licenseIndex
+	groups []string
+}
+
+func tokenizeExpression(expression string) []string {
+	expression = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression)
+	return strings.Fields(expression)
+}
+
+func (e *licenseExpression) peek() string {
+	if e.pos < len(e.tokens) {
+		return e.tokens[e.pos]
+	}
+	return ""
+}
+
+func (e *licenseExpression) next() string {
+	token := e.peek()
+	e.pos++
+	return token
+}
+
+func (e *licenseExpression) parseOr() (LicenseStatus, error) {
+	status, err := e.parseAnd()
+	if err != nil {
+		return "", err
+	}
+	for strings.EqualFold(e.peek(), "OR") {
+		e.next()
+		operand, err := e.parseAnd()
+		if err != nil {
+			return "", err
+		}
+		if statusRank[operand] < statusRank[status] {
+			status = operand
+		}
+	}
+	return status, nil
+}
+
+func (e *licenseExpression) parseAnd() (LicenseStatus, error) {
+	status, err := e.parseWith()
+	if err != nil {
+		return "", err
+	}
+	for strings.EqualFold(e.peek(), "AND") {
+		e.next()
+		operand, err := e.parseWith()
+		if err != nil {
+			return "", err
+		}
+		if statusRank[operand] > statusRank[status] {
+			status = operand
+		}
+	}
+	return status, nil
+}
+
+// parseWith evaluates a license with an optional exception, exceptions do not change the status.
+func (e *licenseExpression) parseWith() (LicenseStatus, error) {
+	status, err := e.parseAtom()
+	if err != nil {
+		return "", err
+	}
+	if strings.EqualFold(e.peek(), "WITH") {
+		e.next()
+		if exception := e.next(); exception == "" || exception == "(" || exception == ")" {
+			return "", fmt.Errorf("license exception expected at %d", e.pos)
+		}
+	}
+	return status, nil
+}
+
+func (e *licenseExpression) parseAtom() (LicenseStatus, error) {
+	token := e.next()
+	switch {
+	case token == "(":
+		status, err := e.parseOr()
+		if err != nil {
+			return "", err
+		}
+		if e.next() != ")" {
+			return "", fmt.Errorf("missing closing parenthesis")
+		}
+		return status, nil
+	case token == "" || token == ")" || strings.EqualFold(token, "AND") || strings.EqualFold(token, "OR") || strings.EqualFold(token, "WITH"):
+		return "", fmt.Errorf("license expected at %d", e.pos)
+	}
+
+	policy := e.index.lookup(token)
+	if policy == nil {
+		return LicenseUnknown, nil
+	}
+	for _, group := range policy.groups {
+		if !containsString(e.groups, group) {
+			e.groups = append(e.groups, group)
+		}
+	}
+	return policy.status, nil
+}
+
+// evaluate returns the status of expression and the license groups it matched.
+func (index licenseIndex) evaluate(expression string) (LicenseStatus, []string, error) {
+	e := &licenseExpression{tokens: tokenizeExpression(expression), index: index}
+	status, err := e.parseOr()
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid license expression %q: %w", expression, err)
+	}
+	if e.pos != len(e.tokens) {
+		return "", nil, fmt.Errorf("invalid license expression %q: unexpected %q", expression, e.peek())
+	}
+	return status, e.groups, nil
+}
+
+// evaluateComponent evaluates the sbom licenses of a component joined with AND,
+// license names are matched as a whole as they may hold spaces and parentheses.
+// Invalid expressions are evaluated as unknown and their parse errors returned along with the result.
+func (index licenseIndex) evaluateComponent(component cdx.Component) (string, LicenseStatus, []string, error) {
+	if component.Licenses == nil {
+		return "", LicenseMissing, nil, nil
+	}
+
+	var operands []string
+	var groups []string
+	var parse_errors []string
+	status := LicenseMissing
+	for _, choice := range *component.Licenses {
+		var operand string
+		var operand_status LicenseStatus
+		var operand_groups []string
+		switch {
+		case choice.License != nil && choice.License.ID == "" && strings.TrimSpace(choice.License.Name) != "":
+			operand = strings.TrimSpace(choice.License.Name)
+			operand_status = LicenseUnknown
+			if policy := index.lookup(operand); policy != nil {
+				operand_status, operand_groups = policy.status, policy.groups
+			}
+		case choice.License != nil && choice.License.ID != "":
+			operand = choice.License.ID
+		case strings.TrimSpace(choice.Expression) != "":
+			operand = strings.TrimSpace(choice.Expression)
+		default:
+			continue
+		}
+
+		if operand_status == "" {
+			var err error
+			operand_status, operand_groups, err = index.evaluate(operand)
+			if err != nil {
+				operand_status, operand_groups = LicenseUnknown, nil
+				parse_errors = append(parse_errors, err.Error())
+			}
+		}
+		if len(*component.Licenses) > 1 && choice.Expression != "" {
+			operand = "(" + operand + ")"
+		}
+		operands = append(operands, operand)
+		if status == LicenseMissing || statusRank[operand_status] > statusRank[status] {
+			status = operand_status
+		}
+		for _, group := range operand_groups {
+			if !containsString(groups, group) {
+				groups = append(groups, group)
+			}
+		}
+	}
+	if len(parse_errors) != 0 {
+		return strings.Join(operands, " AND "), status, groups, errors.New(strings.Join(parse_errors, "; "))
+	}
+	return strings.Join(operands, " AND "), status, groups, nil
+}
+
+// EvaluateLicenseCompliance classifies the licenses of every library component of the sbom by the license groups.
+// A component with an invalid license expression does not fail the report, see ComponentCompliance.Error.
+func EvaluateLicenseCompliance(bom *cdx.BOM, groups LicenseGroupList, config ComplianceConfig) (*ComplianceReport, error) {
+	index, err := newLicenseIndex(groups, config)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ComplianceReport{Counts: make(map[LicenseStatus]int)}
+	if bom.Components == nil {
+		return report, nil
+	}
+	for _, component := range *bom.Components {
+		if component.Type != cdx.ComponentTypeLibrary {
+			continue
+		}
+
+		compliance := ComponentCompliance{
+			Name:    component.Name,
+			Version: component.Version,
+			Purl:    component.PackageURL,
+		}
+		if component.Group != "" {
+			compliance.Name = component.Group + "/" + component.Name
+		}
+		var parse_err error
+		compliance.Licenses, compliance.Status, compliance.Groups, parse_err = index.evaluateComponent(component)
+		if parse_err != nil {
+			compliance.Error = parse_err.Error()
+		}
+		report.Components = append(report.Components, compliance)
+		report.Counts[compliance.Status]++
+	}
+	return report, nil
+}
+
+// EvaluateLicenseComplianceContext evaluates the sbom against the license groups of the server.
+func (depClient *DepTrackClient) EvaluateLicenseComplianceContext(ctx context.Context, bom *cdx.BOM, config ComplianceConfig) (*ComplianceReport, error) {
+	groups, err := depClient.GetLicenseGroupsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return EvaluateLicenseCompliance(bom, groups, config)
+}
+
+func (depClient *DepTrackClient) EvaluateLicenseCompliance(bom *cdx.BOM, config ComplianceConfig) (*ComplianceReport, error) {
+	return depClient.EvaluateLicenseComplianceContext(context.Background(), bom, config)
+}
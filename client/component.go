@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	packageurl "github.com/package-url/packageurl-go"
+	log "github.com/sirupsen/logrus"
+)
+
+const ApiComponent = "/component"
+
+// Component scopes as used by dependency track, the classifier uses the project Classifier values
+const (
+	ComponentScopeRequired = "REQUIRED"
+	ComponentScopeOptional = "OPTIONAL"
+	ComponentScopeExcluded = "EXCLUDED"
+)
+
+// ComponentRef references a component by uuid, as used for component parents.
+type ComponentRef struct {
+	UUID    string `json:"uuid,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type Component struct {
+	UUID        string `json:"uuid,omitempty"`
+	Author      string `json:"author,omitempty"`
+	Publisher   string `json:"publisher,omitempty"`
+	Group       string `json:"group,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Classifier  string `json:"classifier,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description,omitempty"`
+	Copyright   string `json:"copyright,omitempty"`
+	Notes       string `json:"notes,omitempty"`
+	Filename    string `json:"filename,omitempty"`
+	Extension   string `json:"extension,omitempty"`
+	Cpe         string `json:"cpe,omitempty"`
+	SwidTagId   string `json:"swidTagId,omitempty"`
+	// Purl is decoded from the purl string of the api, a purl the parser rejects is left empty
+	// and kept in RawPurl so it is sent back unchanged
+	Purl       packageurl.PackageURL `json:"-"`
+	RawPurl    string                `json:"-"`
+	IsInternal bool                  `json:"isInternal"`
+
+	Md5        string `json:"md5,omitempty"`
+	Sha1       string `json:"sha1,omitempty"`
+	Sha256     string `json:"sha256,omitempty"`
+	Sha384     string `json:"sha384,omitempty"`
+	Sha512     string `json:"sha512,omitempty"`
+	Sha3_256   string `json:"sha3_256,omitempty"`
+	Sha3_384   string `json:"sha3_384,omitempty"`
+	Sha3_512   string `json:"sha3_512,omitempty"`
+	Blake2b256 string `json:"blake2b_256,omitempty"`
+	Blake2b384 string `json:"blake2b_384,omitempty"`
+	Blake2b512 string `json:"blake2b_512,omitempty"`
+	Blake3     string `json:"blake3,omitempty"`
+
+	// License is the license name as found in the sbom, ResolvedLicense the matching server license
+	License            string              `json:"license,omitempty"`
+	LicenseExpression  string              `json:"licenseExpression,omitempty"`
+	LicenseUrl         string              `json:"licenseUrl,omitempty"`
+	ResolvedLicense    *License            `json:"resolvedLicense,omitempty"`
+	ExternalReferences []ExternalReference `json:"externalReferences,omitempty"`
+	Parent             *ComponentRef       `json:"parent,omitempty"`
+	Project            *ProjectRef         `json:"project,omitempty"`
+}
+
+// componentFields has the Component fields without its json methods.
+type componentFields Component
+
+type componentJson struct {
+	*componentFields
+	Purl string `json:"purl,omitempty"`
+}
+
+// PurlString returns the purl, the raw api purl when it could not be parsed and empty when the component has none.
+func (component *Component) PurlString() string {
+	if component.Purl.Type == "" {
+		return component.RawPurl
+	}
+	return component.Purl.ToString()
+}
+
+func (component Component) MarshalJSON() ([]byte, error) {
+	return json.Marshal(componentJson{componentFields: (*componentFields)(&component), Purl: component.PurlString()})
+}
+
+func (component *Component) UnmarshalJSON(data []byte) error {
+	// A reused component must not keep the purl of the previous payload
+	component.Purl = packageurl.PackageURL{}
+	component.RawPurl = ""
+	decoded := componentJson{componentFields: (*componentFields)(component)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Purl == "" {
+		return nil
+	}
+
+	purl, err := packageurl.FromString(decoded.Purl)
+	if err != nil {
+		// A single malformed purl should not fail a whole component page
+		log.Debugf("Component purl parse error, Purl: %s Err: %+v", decoded.Purl, err)
+		component.RawPurl = decoded.Purl
+		return nil
+	}
+	component.Purl = purl
+	return nil
+}
+
+func componentApi(uuid string) string {
+	return ApiComponent + "/" + uuid
+}
+
+func (depClient *DepTrackClient) GetComponentContext(ctx context.Context, uuid string) (*Component, error) {
+	var component Component
+	if err := depClient.GetJsonContext(ctx, componentApi(uuid), &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+func (depClient *DepTrackClient) GetComponent(uuid string) (*Component, error) {
+	return depClient.GetComponentContext(context.Background(), uuid)
+}
+
+// CreateComponentContext adds a hand curated component to the project.
+func (depClient *DepTrackClient) CreateComponentContext(ctx context.Context, project_uuid string, component *Component) (*Component, error) {
+	var created Component
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiComponentProject+"/"+project_uuid, component, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreateComponent(project_uuid string, component *Component) (*Component, error) {
+	return depClient.CreateComponentContext(context.Background(), project_uuid, component)
+}
+
+// UpdateComponentContext replaces the component identified by its UUID.
+func (depClient *DepTrackClient) UpdateComponentContext(ctx context.Context, component *Component) (*Component, error) {
+	if component.UUID == "" {
+		return nil, errors.New("component update requires a uuid")
+	}
+
+	var updated Component
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiComponent, component, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdateComponent(component *Component) (*Component, error) {
+	return depClient.UpdateComponentContext(context.Background(), component)
+}
+
+func (depClient *DepTrackClient) DeleteComponentContext(ctx context.Context, uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, componentApi(uuid), nil, nil)
+}
+
+func (depClient *DepTrackClient) DeleteComponent(uuid string) error {
+	return depClient.DeleteComponentContext(context.Background(), uuid)
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+const ApiConfigProperty = "/configProperty"
+
+type ConfigProperty struct {
+	GroupName     string `json:"groupName"`
+	PropertyName  string `json:"propertyName"`
+	PropertyValue string `json:"propertyValue,omitempty"`
+	PropertyType  string `json:"propertyType,omitempty"`
+	Description   string `json:"description,omitempty"`
+}
+
+type ConfigPropertyList []ConfigProperty
+
+func (property_list ConfigPropertyList) Find(group_name string, property_name string) *ConfigProperty {
+	for i := range property_list {
+		if property_list[i].GroupName == group_name && property_list[i].PropertyName == property_name {
+			return &property_list[i]
+		}
+	}
+	return nil
+}
+
+func (depClient *DepTrackClient) GetConfigPropertiesContext(ctx context.Context) (ConfigPropertyList, error) {
+	var property_list ConfigPropertyList
+	if err := depClient.GetJsonContext(ctx, ApiConfigProperty, &property_list); err != nil {
+		return nil, err
+	}
+	return property_list, nil
+}
+
+func (depClient *DepTrackClient) GetConfigProperties() (ConfigPropertyList, error) {
+	return depClient.GetConfigPropertiesContext(context.Background())
+}
+
+// UpdateConfigPropertyContext sets the value of an existing config property, properties can not be created.
+func (depClient *DepTrackClient) UpdateConfigPropertyContext(ctx context.Context, property *ConfigProperty) (*ConfigProperty, error) {
+	var updated ConfigProperty
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiConfigProperty, property, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdateConfigProperty(property *ConfigProperty) (*ConfigProperty, error) {
+	return depClient.UpdateConfigPropertyContext(context.Background(), property)
+}
@@ -2,18 +2,21 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"deptrack/versioning"
 	"encoding/json"
 	"errors"
-	"time"
 
+	"io"
 	"io/ioutil"
+	"mime/multipart"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
-	retry "github.com/avast/retry-go"
 	packageurl "github.com/package-url/packageurl-go"
-	api_client "github.com/scribe-security/scribe/pkg/client"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -22,22 +25,6 @@ type JSON_LIST []map[string]interface{}
 
 type References []string
 
-type PurlQualifiers struct {
-	AdditionalProp1 string `json:"additionalProp1,omitempty"`
-	AdditionalProp2 string `json:"additionalProp2,omitempty"`
-	AdditionalProp3 string `json:"additionalProp3,omitempty"`
-}
-
-type Purl struct {
-	Scheme     string         `json:"scheme,omitempty"`
-	Type       string         `json:"type,omitempty"`
-	Namespace  string         `json:"namespace,omitempty"`
-	Name       string         `json:"name,omitempty"`
-	Version    string         `json:"version,omitempty"`
-	Qualifiers PurlQualifiers `json:"qualifiers,omitempty"`
-	Subpath    string         `json:"subpath,omitempty"`
-}
-
 type DepTrackPermission struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
@@ -55,6 +42,8 @@ type DepTrackSbomPost struct {
 	AutoCreate     string `json:"autoCreate,omitempty"`
 	ProjectName    string `json:"projectName,omitempty"`
 	ProjectVersion string `json:"projectVersion,omitempty"`
+	// ProjectTags is the comma separated list of tags set on the project, see TagsParam
+	ProjectTags string `json:"projectTags,omitempty"`
 }
 
 type LatestVersionParams struct {
@@ -82,6 +71,7 @@ type GetComponentsIdentityParams struct {
 
 type GetVulnerabilityByUUIDParams struct {
 	Suppressed bool `json:"suppressed,omitempty"`
+	PaginationParams
 }
 
 type VersionResponse struct {
@@ -89,6 +79,16 @@ type VersionResponse struct {
 	Namespace      string `json:"namespace,omitempty"`
 	Name           string `json:"name,omitempty"`
 	LatestVersion  string `json:"latestVersion,omitempty"`
+	Published      int64  `json:"published,omitempty"`
+	LastCheck      int64  `json:"lastCheck,omitempty"`
+}
+
+// PublishedTime is the release date of the latest version, zero when the repository does not report it.
+func (resp *VersionResponse) PublishedTime() time.Time {
+	if resp.Published == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, resp.Published*int64(time.Millisecond))
 }
 
 type DepTrackSbomPostResponse struct {
@@ -97,67 +97,52 @@ type DepTrackSbomPostResponse struct {
 }
 
 type DepTrackClient struct {
-	*api_client.ApiClient
-	token       string
-	accessToken string
+	token         string
+	accessToken   string
+	apiServerPath string
+	httpClient    *http.Client
+	limiter       *RateLimiter
+	serverInfo    serverInfoCache
 }
 
-type Cwe struct {
-	cweId int `json:"vulnId,omitempty"`
-}
-
-type Vulnraibility struct {
-	VulnId          string  `json:"vulnId,omitempty"`
-	Source          string  `json:"source,omitempty"`
-	Description     string  `json:"description,omitempty"`
-	CvssV2BaseScore float64 `json:"cvssV2BaseScore,omitempty"`
-	CvssV3BaseScore float64 `json:"cvssV3BaseScore,omitempty"`
-	Cwe             Cwe     `json:"cwe,omitempty"`
-	References      string  `json:"references,omitempty"`
-	Severity        string  `json:"severity,omitempty"`
-	// TBD: Add support to time parsing
-	//Published       string  `json:"published,omitempty"`
-	//Updated         time.Time `json:"updated,omitempty"`
-}
 type SbomProcessingState struct {
-	Processing bool `json:"processing,omitempty"`
+	Processing *bool `json:"processing,omitempty"`
 }
 
 type PaginationParams struct {
-	Offset string `json:"offset,omitempty"`
-	Limit  string `json:"limit,omitempty"`
-}
-
-type Component struct {
-	Author    string `json:"author,omitempty"`
-	Publisher string `json:"publisher,omitempty"`
-	Group     string `json:"group,omitempty"`
-	Name      string `json:"name,omitempty"`
-	Version   string `json:"version,omitempty"`
-	Filename  string `json:"filename,omitempty"`
-	Extension string `json:"extension,omitempty"`
-	Md5       string `json:"md5,omitempty"`
-	Sha1      string `json:"sha1,omitempty"`
-	Sha256    string `json:"sha256,omitempty"`
-	Cpe       string `json:"cpe,omitempty"`
-	Purl      Purl   `json:"-,omitempty"`
-	UUID      string `json:"uuid,omitempty"`
+	Offset int `json:"offset,omitempty"`
+	Limit  int `json:"limit,omitempty"`
 }
 
 type Project struct {
-	Name                   string       `json:"name,omitempty"`
-	UUID                   string       `json:"uuid,omitempty"`
-	LastInheritedRiskScore float64      `json:"lastInheritedRiskScore,omitempty"`
-	LastBomImportFormat    string       `json:"lastBomImportFormat,omitempty"`
-	Active                 bool         `json:"active,omitempty"`
-	Metrics                Metrics_stat `json:"metrics,omitempty"`
-}
-
-type Metrics_stat struct {
-	Vulnerabilities      int `json:"vulnerabilities,omitempty"`
-	VulnerableComponents int `json:"vulnerableComponents,omitempty"`
-	Component            int `json:"component,omitempty"`
-}
+	Name                   string              `json:"name,omitempty"`
+	Version                string              `json:"version,omitempty"`
+	Group                  string              `json:"group,omitempty"`
+	Publisher              string              `json:"publisher,omitempty"`
+	Description            string              `json:"description,omitempty"`
+	Classifier             string              `json:"classifier,omitempty"`
+	Purl                   string              `json:"purl,omitempty"`
+	Cpe                    string              `json:"cpe,omitempty"`
+	SwidTagId              string              `json:"swidTagId,omitempty"`
+	Tags                   []Tag               `json:"tags,omitempty"`
+	ExternalReferences     []ExternalReference `json:"externalReferences,omitempty"`
+	Parent                 *ProjectRef         `json:"parent,omitempty"`
+	Children               []Project           `json:"children,omitempty"`
+	UUID                   string              `json:"uuid,omitempty"`
+	LastInheritedRiskScore float64             `json:"lastInheritedRiskScore,omitempty"`
+	LastBomImportFormat    string              `json:"lastBomImportFormat,omitempty"`
+	Active                 *bool               `json:"active,omitempty"`
+	Metrics                ProjectMetrics      `json:"metrics,omitempty"`
+}
+
+// IsActive reports whether the project is active, a nil Active is left to the server default which is active.
+// Set Active to false to deactivate the project on update.
+func (project *Project) IsActive() bool {
+	return project.Active == nil || *project.Active
+}
+
+// Deprecated: use ProjectMetrics
+type Metrics_stat = ProjectMetrics
 
 type ProjectList []Project
 
@@ -165,6 +150,33 @@ type PurlVersionStruct struct {
 	CurrentVersion *packageurl.PackageURL
 	LatestVersion  *packageurl.PackageURL
 	IsVersionEquel bool
+	// Comparison is -1, 0 or 1 as current is older, equal or newer than latest
+	Comparison int
+	Upgrade    versioning.UpgradeClass
+	// LatestPublished is the release date of latest, zero when unknown
+	LatestPublished time.Time
+	// CurrentPublished is the release date of current, zero when unknown, see GetPurlVersionsBySbom
+	CurrentPublished time.Time
+}
+
+// NewPurlVersionStruct compares current with latest using the version scheme of the purl type.
+// Purl types without a known scheme fall back to purl string equality with an unknown upgrade class.
+func NewPurlVersionStruct(current *packageurl.PackageURL, latest *packageurl.PackageURL) PurlVersionStruct {
+	purl_version := PurlVersionStruct{CurrentVersion: current, LatestVersion: latest, Upgrade: versioning.UpgradeUnknown}
+	if current == nil || latest == nil {
+		return purl_version
+	}
+
+	cmp, upgrade, err := versioning.Classify(current.Type, current.Version, latest.Version)
+	if err != nil {
+		log.Debugf("Version compare failed, Purl: %s Latest: %s Err: %+v", current.ToString(), latest.Version, err)
+		purl_version.IsVersionEquel = current.ToString() == latest.ToString()
+		return purl_version
+	}
+	purl_version.IsVersionEquel = cmp == 0
+	purl_version.Comparison = cmp
+	purl_version.Upgrade = upgrade
+	return purl_version
 }
 
 type VulnraibilityListMap map[string]VulnraibilityList
@@ -183,28 +195,31 @@ const (
 	ApiUserLoginPath          = "user/login"
 	BomField                  = "bom"
 	TeamField                 = "team"
-	DefaultMaxPaginationLimit = "10000"
+	DefaultMaxPaginationLimit = 10000
 )
 
-var DefaultPagination = PaginationParams{Offset: "0", Limit: DefaultMaxPaginationLimit}
+var DefaultPagination = PaginationParams{Offset: 0, Limit: DefaultMaxPaginationLimit}
 
 func NewDepTrackClient(access_token string, api_server_path string) (*DepTrackClient, error) {
-	cfg := api_client.ServiceCfg{ApiToken: access_token, Url: api_server_path, Enable: true}
-	client, err := api_client.NewApiClient(&cfg, false, 0)
-	if err != nil {
-		return nil, err
-	}
-	return &DepTrackClient{ApiClient: client}, nil
+	return &DepTrackClient{
+		token:         access_token,
+		apiServerPath: api_server_path,
+		httpClient:    &http.Client{},
+	}, nil
 }
 
 func (depClient *DepTrackClient) Login(username string, password string) error {
+	return depClient.LoginContext(context.Background(), username, password)
+}
+
+func (depClient *DepTrackClient) LoginContext(ctx context.Context, username string, password string) error {
 
 	login_values := url.Values{
 		"username": {username}, //Read from env DEPEND_TRACK_USER
 		"password": {password}, //Read from env DEPEND_TRACK_PASS
 	}
 
-	resp, err := depClient.Post(ApiUserLoginPath, "application/x-www-form-urlencoded", strings.NewReader(login_values.Encode()))
+	resp, err := depClient.PostContext(ctx, ApiUserLoginPath, FormUrlEncodedContentType, strings.NewReader(login_values.Encode()))
 	if err != nil {
 		return err
 	}
@@ -216,13 +231,17 @@ func (depClient *DepTrackClient) Login(username string, password string) error {
 	}
 
 	log.Info("Access token: ", string(login_response_bytes))
-	depClient.Cfg.Token = string(login_response_bytes)
+	depClient.accessToken = string(login_response_bytes)
 	return nil
 }
 
 func (depClient *DepTrackClient) GetJsonList(api string) (JSON_LIST, error) {
+	return depClient.GetJsonListContext(context.Background(), api)
+}
+
+func (depClient *DepTrackClient) GetJsonListContext(ctx context.Context, api string) (JSON_LIST, error) {
 	var dst JSON_LIST
-	err := depClient.GetJson(api, &dst)
+	err := depClient.GetJsonContext(ctx, api, &dst)
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +249,11 @@ func (depClient *DepTrackClient) GetJsonList(api string) (JSON_LIST, error) {
 }
 
 func (depClient *DepTrackClient) GetTeam() (JSON_LIST, error) {
-	return depClient.GetJsonList(TeamField)
+	return depClient.GetTeamContext(context.Background())
+}
+
+func (depClient *DepTrackClient) GetTeamContext(ctx context.Context) (JSON_LIST, error) {
+	return depClient.GetJsonListContext(ctx, TeamField)
 }
 
 func (depClient *DepTrackClient) filterComponents(bom *cdx.BOM) {
@@ -257,32 +280,50 @@ func (depClient *DepTrackClient) checkComponentType(component cdx.Component) boo
 }
 
 func (depClient *DepTrackClient) PostSbom(api string, deptrack_params *DepTrackSbomPost, bom *cdx.BOM, response *DepTrackSbomPostResponse) error {
-	depClient.filterComponents(bom)
+	return depClient.PostSbomContext(context.Background(), api, deptrack_params, bom, response)
+}
 
-	buf := new(bytes.Buffer)
+func (depClient *DepTrackClient) PostSbomContext(ctx context.Context, api string, deptrack_params *DepTrackSbomPost, bom *cdx.BOM, response *DepTrackSbomPostResponse) error {
+	depClient.filterComponents(bom)
 
 	// 2DO removing FILE and DEP graphs (to much work for deptrack)
 	bom.Dependencies = nil
+
+	return depClient.postMultipartContext(ctx, api, BomField, deptrack_params.ProjectName, deptrack_params, func(part io.Writer) error {
+		encoder := cdx.NewBOMEncoder(part, cdx.BOMFileFormatJSON)
+		encoder.SetPretty(true)
+		return encoder.Encode(bom)
+	}, response)
+}
+
+// postMultipartContext posts a multipart form with params as fields and the file written by encode under field.
+func (depClient *DepTrackClient) postMultipartContext(ctx context.Context, field string, api string, file_name string, params interface{}, encode func(io.Writer) error, response interface{}) error {
+	buf := new(bytes.Buffer)
+
 	var extraParams map[string]string
-	v, err := json.Marshal(deptrack_params)
+	v, err := json.Marshal(params)
 	if err != nil {
 		return err
 	}
 	json.Unmarshal([]byte(v), &extraParams)
 
-	multipart_writer, part, err := depClient.MultipartWriter(api, deptrack_params.ProjectName, extraParams, buf)
+	multipart_writer := multipart.NewWriter(buf)
+	for key, value := range extraParams {
+		if err := multipart_writer.WriteField(key, value); err != nil {
+			return err
+		}
+	}
+	part, err := multipart_writer.CreateFormFile(field, file_name)
 	if err != nil {
 		return err
 	}
 
-	encoder := cdx.NewBOMEncoder(part, cdx.BOMFileFormatJSON)
-	encoder.SetPretty(true)
-	err = encoder.Encode(bom)
+	err = encode(part)
 	if err != nil {
 		return err
 	}
 	multipart_writer.Close()
-	resp, err := depClient.Post(BomField, multipart_writer.FormDataContentType(), buf)
+	resp, err := depClient.PostContext(ctx, api, multipart_writer.FormDataContentType(), buf)
 	if err != nil {
 		return err
 	}
@@ -292,20 +333,23 @@ func (depClient *DepTrackClient) PostSbom(api string, deptrack_params *DepTrackS
 	if err != nil {
 		return err
 	}
-
-	err = json.Unmarshal(v, &response)
-	if err != nil {
-		return err
+	// Any 2xx is a successful upload, the body is only decoded when the caller expects one
+	if response == nil || len(bytes.TrimSpace(v)) == 0 {
+		return nil
 	}
 
-	return err
+	return json.Unmarshal(v, response)
 }
 
 func (depClient *DepTrackClient) GetRepositoryLatest(PURL string) (*VersionResponse, error) {
+	return depClient.GetRepositoryLatestContext(context.Background(), PURL)
+}
+
+func (depClient *DepTrackClient) GetRepositoryLatestContext(ctx context.Context, PURL string) (*VersionResponse, error) {
 	var latestVersion VersionResponse
 	params := LatestVersionParams{Purl: PURL}
 
-	err := depClient.GetJsonWithParams(ApiRepositoryLatest, params, &latestVersion)
+	err := depClient.GetJsonWithParamsContext(ctx, ApiRepositoryLatest, params, &latestVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -314,8 +358,12 @@ func (depClient *DepTrackClient) GetRepositoryLatest(PURL string) (*VersionRespo
 }
 
 func (depClient *DepTrackClient) GetComponentsIdentity(params GetComponentsIdentityParams) (ComponentList, error) {
+	return depClient.GetComponentsIdentityContext(context.Background(), params)
+}
+
+func (depClient *DepTrackClient) GetComponentsIdentityContext(ctx context.Context, params GetComponentsIdentityParams) (ComponentList, error) {
 	var component_list ComponentList
-	if err := depClient.GetJsonWithParams(ApiComponentIdentity, params, &component_list); err != nil {
+	if err := depClient.GetJsonWithParamsContext(ctx, ApiComponentIdentity, params, &component_list); err != nil {
 		return nil, err
 	}
 
@@ -323,31 +371,59 @@ func (depClient *DepTrackClient) GetComponentsIdentity(params GetComponentsIdent
 }
 
 func (depClient *DepTrackClient) GetProjectLookup(params GetProjectLookupParams) (*Project, error) {
+	return depClient.GetProjectLookupContext(context.Background(), params)
+}
+
+func (depClient *DepTrackClient) GetProjectLookupContext(ctx context.Context, params GetProjectLookupParams) (*Project, error) {
 
 	var project Project
-	if err := depClient.GetJsonWithParams(ApiProjectLookup, params, &project); err != nil {
+	if err := depClient.GetJsonWithParamsContext(ctx, ApiProjectLookup, params, &project); err != nil {
 		return nil, err
 	}
 	return &project, nil
 }
 
+// ProjectExistsContext treats a not found project lookup as a missing project rather than a failure.
+func (depClient *DepTrackClient) ProjectExistsContext(ctx context.Context, params GetProjectLookupParams) (bool, error) {
+	_, err := depClient.GetProjectLookupContext(ctx, params)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (depClient *DepTrackClient) ProjectExists(params GetProjectLookupParams) (bool, error) {
+	return depClient.ProjectExistsContext(context.Background(), params)
+}
+
 func (depClient *DepTrackClient) GetProject(params GetProjectParams) (ProjectList, error) {
+	return depClient.GetProjectContext(context.Background(), params)
+}
+
+func (depClient *DepTrackClient) GetProjectContext(ctx context.Context, params GetProjectParams) (ProjectList, error) {
 	var project_list ProjectList
-	if err := depClient.GetJsonWithParams(ApiProject, params, &project_list); err != nil {
+	if err := depClient.GetJsonWithParamsContext(ctx, ApiProject, params, &project_list); err != nil {
 		return nil, err
 	}
 	return project_list, nil
 }
 
 func (depClient *DepTrackClient) GetComponentsByProjectUUID(uuid string, pagination_param *PaginationParams) (ComponentList, error) {
+	return depClient.GetComponentsByProjectUUIDContext(context.Background(), uuid, pagination_param)
+}
+
+func (depClient *DepTrackClient) GetComponentsByProjectUUIDContext(ctx context.Context, uuid string, pagination_param *PaginationParams) (ComponentList, error) {
 	var component_list ComponentList
 	full_api := ApiComponentProject + "/" + uuid
 	if pagination_param != nil {
-		if err := depClient.GetJsonWithParams(full_api, pagination_param, &component_list); err != nil {
+		if err := depClient.GetJsonWithParamsContext(ctx, full_api, pagination_param, &component_list); err != nil {
 			return nil, err
 		}
 	} else {
-		if err := depClient.GetJson(full_api, &component_list); err != nil {
+		if err := depClient.GetJsonContext(ctx, full_api, &component_list); err != nil {
 			return nil, err
 		}
 	}
@@ -356,36 +432,62 @@ func (depClient *DepTrackClient) GetComponentsByProjectUUID(uuid string, paginat
 }
 
 func (depClient *DepTrackClient) GetVulnerabilityComponenetByUUID(uuid string, isSupported bool, pagination_param *PaginationParams) (VulnraibilityList, error) {
+	return depClient.GetVulnerabilityComponenetByUUIDContext(context.Background(), uuid, isSupported, pagination_param)
+}
+
+// GetVulnerabilityComponenetByUUIDContext lists the component vulnerabilities, suppressed ones are included if isSupported is set.
+func (depClient *DepTrackClient) GetVulnerabilityComponenetByUUIDContext(ctx context.Context, uuid string, isSupported bool, pagination_param *PaginationParams) (VulnraibilityList, error) {
 
 	var vulnraibilityList VulnraibilityList
 	full_api := ApiVulnrabilityComponent + "/" + uuid
+	params := GetVulnerabilityByUUIDParams{Suppressed: isSupported}
 	if pagination_param != nil {
-		if err := depClient.GetJsonWithParams(full_api, pagination_param, &vulnraibilityList); err != nil {
-			return nil, err
-		}
-	} else {
-		if err := depClient.GetJson(full_api, &vulnraibilityList); err != nil {
-			return nil, err
-		}
+		params.PaginationParams = *pagination_param
+	}
+	if err := depClient.GetJsonWithParamsContext(ctx, full_api, params, &vulnraibilityList); err != nil {
+		return nil, err
 	}
 
 	return vulnraibilityList, nil
 }
 
 func (depClient *DepTrackClient) GetLatestVersion(PURL string) (*packageurl.PackageURL, *packageurl.PackageURL, bool, error) {
-	latest_version_response, err := depClient.GetRepositoryLatest(PURL)
+	return depClient.GetLatestVersionContext(context.Background(), PURL)
+}
+
+func (depClient *DepTrackClient) GetLatestVersionContext(ctx context.Context, PURL string) (*packageurl.PackageURL, *packageurl.PackageURL, bool, error) {
+	purl_version, err := depClient.GetPurlVersionContext(ctx, PURL)
 	if err != nil {
 		return nil, nil, false, err
 	}
 
+	return purl_version.LatestVersion, purl_version.CurrentVersion, purl_version.IsVersionEquel, nil
+}
+
+func (depClient *DepTrackClient) GetPurlVersion(PURL string) (*PurlVersionStruct, error) {
+	return depClient.GetPurlVersionContext(context.Background(), PURL)
+}
+
+// GetPurlVersionContext compares PURL with the latest version known to the server repositories.
+func (depClient *DepTrackClient) GetPurlVersionContext(ctx context.Context, PURL string) (*PurlVersionStruct, error) {
+	latest_version_response, err := depClient.GetRepositoryLatestContext(ctx, PURL)
+	if err != nil {
+		return nil, err
+	}
+
 	parsed_purl, err := packageurl.FromString(PURL)
 	if err != nil {
-		return nil, nil, false, err
+		return nil, err
 	}
 
-	latest_parsed_purl, _ := depClient.LatestToPurl(parsed_purl, latest_version_response)
+	latest_parsed_purl, err := depClient.LatestToPurl(parsed_purl, latest_version_response)
+	if err != nil {
+		return nil, err
+	}
 
-	return latest_parsed_purl, &parsed_purl, CmpPurl(&parsed_purl, latest_parsed_purl), err
+	purl_version := NewPurlVersionStruct(&parsed_purl, latest_parsed_purl)
+	purl_version.LatestPublished = latest_version_response.PublishedTime()
+	return &purl_version, nil
 }
 
 func (depClient *DepTrackClient) LatestToPurl(base packageurl.PackageURL, resp *VersionResponse) (*packageurl.PackageURL, error) {
@@ -397,18 +499,25 @@ func (depClient *DepTrackClient) LatestToPurl(base packageurl.PackageURL, resp *
 	return &normlized_new_purl, nil
 }
 
+// CmpPurl reports whether a and b have equal versions, by the version scheme of the purl type when known.
 func CmpPurl(a *packageurl.PackageURL, b *packageurl.PackageURL) bool {
-	return a.ToString() == b.ToString()
+	return NewPurlVersionStruct(a, b).IsVersionEquel
 }
 
 func (depClient *DepTrackClient) GetVulnraibilityList(PURL string) (VulnraibilityList, error) {
-	component_list, err := depClient.GetComponentsIdentity(GetComponentsIdentityParams{Purl: PURL})
+	return depClient.GetVulnraibilityListContext(context.Background(), PURL)
+}
+
+// GetVulnraibilityListContext lists the vulnerabilities of the components with the purl, suppressed ones are left out.
+// Use GetFindings for the suppressed findings and their analysis.
+func (depClient *DepTrackClient) GetVulnraibilityListContext(ctx context.Context, PURL string) (VulnraibilityList, error) {
+	component_list, err := depClient.GetAllComponentsIdentityContext(ctx, GetComponentsIdentityParams{Purl: PURL})
 	var final_vulnraibility_list VulnraibilityList
 	if err != nil {
 		return nil, err
 	}
 	for _, componenet := range component_list {
-		vulnraibility_list, err := depClient.GetVulnerabilityComponenetByUUID(componenet.UUID, true, &DefaultPagination)
+		vulnraibility_list, err := depClient.GetAllVulnerabilityComponenetByUUIDContext(ctx, componenet.UUID, false)
 		if err != nil {
 			return nil, err
 		}
@@ -419,76 +528,147 @@ func (depClient *DepTrackClient) GetVulnraibilityList(PURL string) (Vulnraibilit
 }
 
 func (depClient *DepTrackClient) GetLatestVersionBySbom(bom *cdx.BOM) (PurlVersionStructMap, error) {
-	components_map := make(PurlVersionStructMap)
+	return depClient.GetLatestVersionBySbomContext(context.Background(), bom)
+}
 
-	for _, component := range *bom.Components {
+func (depClient *DepTrackClient) GetLatestVersionBySbomContext(ctx context.Context, bom *cdx.BOM) (PurlVersionStructMap, error) {
+	components_map, err := depClient.GetLatestVersionBySbomWithOptions(ctx, bom, DefaultWalkOptions)
+	var walk_err *WalkError
+	if errors.As(err, &walk_err) {
+		// Components without a known latest version are skipped
+		return components_map, nil
+	}
+	return components_map, err
+}
 
-		if !depClient.checkComponentType(component) {
-			continue
+// GetLatestVersionBySbomWithOptions looks up the latest version of every library component concurrently, keyed by name.
+// Same named components overwrite each other, GetPurlVersionsBySbom keys them by purl.
+// On component errors the successful lookups are returned along with a *WalkError.
+func (depClient *DepTrackClient) GetLatestVersionBySbomWithOptions(ctx context.Context, bom *cdx.BOM, opts WalkOptions) (PurlVersionStructMap, error) {
+	results, err := depClient.purlVersionsBySbomContext(ctx, bom, opts, false)
+	var walk_err *WalkError
+	if err != nil && !errors.As(err, &walk_err) {
+		return nil, err
+	}
+
+	components_map := make(PurlVersionStructMap)
+	for index, result := range results {
+		if result != nil {
+			components_map[(*bom.Components)[index].Name] = *result
 		}
-		current_version, latest_version, is_version_equel, err := depClient.GetLatestVersion(component.PackageURL)
-		if err != nil {
-			log.Debugf("Get Latest version error skipping, Purl: %s Err: %+v", component.PackageURL, err)
-			continue
+	}
+	return components_map, err
+}
+
+func (depClient *DepTrackClient) GetPurlVersionsBySbom(bom *cdx.BOM) (PurlVersionStructMap, error) {
+	return depClient.GetPurlVersionsBySbomContext(context.Background(), bom, DefaultWalkOptions)
+}
+
+// GetPurlVersionsBySbomContext looks up the latest version of every library component concurrently, keyed by purl.
+// The release date of the current version is looked up as well on servers serving the integrity metadata.
+// On component errors the successful lookups are returned along with a *WalkError.
+func (depClient *DepTrackClient) GetPurlVersionsBySbomContext(ctx context.Context, bom *cdx.BOM, opts WalkOptions) (PurlVersionStructMap, error) {
+	results, err := depClient.purlVersionsBySbomContext(ctx, bom, opts, depClient.SupportsContext(ctx, CapabilityIntegrityMetadata))
+	var walk_err *WalkError
+	if err != nil && !errors.As(err, &walk_err) {
+		return nil, err
+	}
+
+	components_map := make(PurlVersionStructMap)
+	for index, result := range results {
+		if result != nil {
+			components_map[(*bom.Components)[index].PackageURL] = *result
 		}
-		components_map[component.Name] = PurlVersionStruct{current_version, latest_version, is_version_equel}
 	}
+	return components_map, err
+}
 
-	return components_map, nil
+// purlVersionsBySbomContext returns the version of the bom component at the same index, nil for skipped and failed components.
+func (depClient *DepTrackClient) purlVersionsBySbomContext(ctx context.Context, bom *cdx.BOM, opts WalkOptions, release_dates bool) ([]*PurlVersionStruct, error) {
+	if bom.Components == nil {
+		return nil, nil
+	}
+
+	results := make([]*PurlVersionStruct, len(*bom.Components))
+	err := depClient.WalkSbomComponents(ctx, bom, opts, func(ctx context.Context, index int, component cdx.Component) error {
+		purl_version, err := depClient.GetPurlVersionContext(ctx, component.PackageURL)
+		if err != nil {
+			return err
+		}
+		if release_dates {
+			// A missing release date only leaves the libyears unknown
+			metadata, err := depClient.GetIntegrityMetadataContext(ctx, component.PackageURL)
+			if err != nil {
+				log.Debugf("Release date unknown, Purl: %s Err: %+v", component.PackageURL, err)
+			} else {
+				purl_version.CurrentPublished = metadata.PublishedTime()
+			}
+		}
+		results[index] = purl_version
+		return nil
+	})
+	return results, err
 }
 
 func (depClient *DepTrackClient) GetVulnraibilityListBySbom(bom *cdx.BOM) (VulnraibilityListMap, error) {
+	return depClient.GetVulnraibilityListBySbomContext(context.Background(), bom)
+}
+
+func (depClient *DepTrackClient) GetVulnraibilityListBySbomContext(ctx context.Context, bom *cdx.BOM) (VulnraibilityListMap, error) {
+	opts := DefaultWalkOptions
+	opts.FailFast = true
+	return depClient.GetVulnraibilityListBySbomWithOptions(ctx, bom, opts)
+}
+
+// GetVulnraibilityListBySbomWithOptions looks up the vulnerabilities of every library component concurrently.
+// On component errors the successful lookups are returned along with a *WalkError.
+func (depClient *DepTrackClient) GetVulnraibilityListBySbomWithOptions(ctx context.Context, bom *cdx.BOM, opts WalkOptions) (VulnraibilityListMap, error) {
 	components_map := make(VulnraibilityListMap)
+	if bom.Components == nil {
+		return components_map, nil
+	}
 
-	for _, component := range *bom.Components {
-		if !depClient.checkComponentType(component) {
-			continue
-		}
-		vulnraibility_list, err := depClient.GetVulnraibilityList(component.PackageURL)
+	results := make([]VulnraibilityList, len(*bom.Components))
+	err := depClient.WalkSbomComponents(ctx, bom, opts, func(ctx context.Context, index int, component cdx.Component) error {
+		vulnraibility_list, err := depClient.GetVulnraibilityListContext(ctx, component.PackageURL)
 		if err != nil {
-			log.Debugf("Get vulnraibility error skipping, Purl: %s Err: %+v", component.PackageURL, err)
-			return nil, err
+			return err
 		}
+		results[index] = vulnraibility_list
+		return nil
+	})
+	var walk_err *WalkError
+	if err != nil && !errors.As(err, &walk_err) {
+		return nil, err
+	}
 
+	for index, vulnraibility_list := range results {
 		if len(vulnraibility_list) == 0 {
 			continue
 		}
-
-		components_map[component.Name] = vulnraibility_list
+		components_map[(*bom.Components)[index].Name] = vulnraibility_list
 	}
-
-	return components_map, nil
+	return components_map, err
 }
 
+// GetBomStateByToken reports whether the server is still processing the upload, see GetBomProcessingState.
 func (depClient *DepTrackClient) GetBomStateByToken(sbom_uuid string) (bool, error) {
-	var sbomProcessingState SbomProcessingState
-	sbom_token_query := ApiSbomTokenQuery + "/" + sbom_uuid
-	if err := depClient.GetJson(sbom_token_query, &sbomProcessingState); err != nil {
+	return depClient.GetBomStateByTokenContext(context.Background(), sbom_uuid)
+}
+
+func (depClient *DepTrackClient) GetBomStateByTokenContext(ctx context.Context, sbom_uuid string) (bool, error) {
+	state, err := depClient.GetBomProcessingStateContext(ctx, sbom_uuid)
+	if err != nil {
 		return false, err
 	}
 
-	return sbomProcessingState.Processing, nil
+	return state == BomStateProcessing, nil
 }
 
 func (depClient *DepTrackClient) WaitforSbomFinishUpload(sbom_uuid string) (bool, error) {
-	DefaultDelay := 50 * time.Millisecond
+	return depClient.WaitforSbomFinishUploadContext(context.Background(), sbom_uuid)
+}
 
-	err := retry.Do(
-		func() error {
-			is_finished, err := depClient.GetBomStateByToken(sbom_uuid)
-			if err != nil {
-				return err
-			}
-			if !is_finished {
-				return nil
-			}
-			return errors.New("Processing")
-		},
-		retry.Delay(DefaultDelay),
-	)
-	if err != nil {
-		return false, err
-	}
-	// If not processing return true
-	return true, nil
+func (depClient *DepTrackClient) WaitforSbomFinishUploadContext(ctx context.Context, sbom_uuid string) (bool, error) {
+	return depClient.WaitforSbomFinishUploadWithOptions(ctx, sbom_uuid, DefaultWaitOptions)
 }
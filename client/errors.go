@@ -0,0 +1,76 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	RequestIdHeader = "X-Request-Id"
+	MaxErrorBodyLen = 4096
+)
+
+// APIError is returned for every api server response with a non 2xx status code.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+	Body       string
+	RequestId  string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("dependency track api error, Method: %s Endpoint: %s Status: %d %s", e.Method, e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.RequestId != "" {
+		msg += " RequestId: " + e.RequestId
+	}
+	if e.Body != "" {
+		msg += " Body: " + e.Body
+	}
+	return msg
+}
+
+// newAPIError reads up to MaxErrorBodyLen bytes of the response body into an APIError.
+func newAPIError(resp *http.Response, method string, endpoint string) *APIError {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, MaxErrorBodyLen+1))
+	body_str := string(body)
+	if len(body) > MaxErrorBodyLen {
+		body_str = string(body[:MaxErrorBodyLen]) + "...(truncated)"
+	}
+
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+		Body:       body_str,
+		RequestId:  resp.Header.Get(RequestIdHeader),
+	}
+}
+
+// StatusCode returns the http status of an APIError in err's chain, zero if there is none.
+func StatusCode(err error) int {
+	var api_err *APIError
+	if errors.As(err, &api_err) {
+		return api_err.StatusCode
+	}
+	return 0
+}
+
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
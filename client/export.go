@@ -0,0 +1,96 @@
+package client
+
+import (
+	"bytes"
+	"context"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+const (
+	ApiBomProject    = "/bom/cyclonedx/project"
+	ApiBomComponent  = "/bom/cyclonedx/component"
+	CycloneDxXmlType = "application/vnd.cyclonedx+xml"
+)
+
+// Bom export formats and variants as used by dependency track
+const (
+	BomFormatJson                 = "json"
+	BomFormatXml                  = "xml"
+	BomVariantInventory           = "inventory"
+	BomVariantWithVulnerabilities = "withVulnerabilities"
+	BomVariantVDR                 = "vdr"
+)
+
+type ExportBomParams struct {
+	Format  string `json:"format,omitempty"`
+	Variant string `json:"variant,omitempty"`
+}
+
+func bomMediaType(format string) string {
+	if format == BomFormatXml {
+		return CycloneDxXmlType
+	}
+	return CycloneDxJsonType
+}
+
+func bomFileFormat(format string) cdx.BOMFileFormat {
+	if format == BomFormatXml {
+		return cdx.BOMFileFormatXML
+	}
+	return cdx.BOMFileFormatJSON
+}
+
+// ExportProjectBOMRawContext returns the project bom as served, an empty format defaults to json.
+func (depClient *DepTrackClient) ExportProjectBOMRawContext(ctx context.Context, project_uuid string, format string, variant string) ([]byte, error) {
+	params := ExportBomParams{Format: format, Variant: variant}
+	return depClient.GetRawContext(ctx, ApiBomProject+"/"+project_uuid, params, bomMediaType(format))
+}
+
+func (depClient *DepTrackClient) ExportProjectBOMRaw(project_uuid string, format string, variant string) ([]byte, error) {
+	return depClient.ExportProjectBOMRawContext(context.Background(), project_uuid, format, variant)
+}
+
+// ExportProjectBOMContext returns the decoded project bom.
+// The vulnerabilities of the withVulnerabilities and vdr variants are not modeled by cdx.BOM,
+// use ExportProjectBOMRawContext or ExportVEXContext to read them.
+func (depClient *DepTrackClient) ExportProjectBOMContext(ctx context.Context, project_uuid string, format string, variant string) (*cdx.BOM, error) {
+	raw, err := depClient.ExportProjectBOMRawContext(ctx, project_uuid, format, variant)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBom(raw, format)
+}
+
+func (depClient *DepTrackClient) ExportProjectBOM(project_uuid string, format string, variant string) (*cdx.BOM, error) {
+	return depClient.ExportProjectBOMContext(context.Background(), project_uuid, format, variant)
+}
+
+func (depClient *DepTrackClient) ExportComponentBOMRawContext(ctx context.Context, component_uuid string, format string) ([]byte, error) {
+	params := ExportBomParams{Format: format}
+	return depClient.GetRawContext(ctx, ApiBomComponent+"/"+component_uuid, params, bomMediaType(format))
+}
+
+func (depClient *DepTrackClient) ExportComponentBOMRaw(component_uuid string, format string) ([]byte, error) {
+	return depClient.ExportComponentBOMRawContext(context.Background(), component_uuid, format)
+}
+
+func (depClient *DepTrackClient) ExportComponentBOMContext(ctx context.Context, component_uuid string, format string) (*cdx.BOM, error) {
+	raw, err := depClient.ExportComponentBOMRawContext(ctx, component_uuid, format)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBom(raw, format)
+}
+
+func (depClient *DepTrackClient) ExportComponentBOM(component_uuid string, format string) (*cdx.BOM, error) {
+	return depClient.ExportComponentBOMContext(context.Background(), component_uuid, format)
+}
+
+func decodeBom(raw []byte, format string) (*cdx.BOM, error) {
+	var bom cdx.BOM
+	if err := cdx.NewBOMDecoder(bytes.NewReader(raw), bomFileFormat(format)).Decode(&bom); err != nil {
+		return nil, err
+	}
+	return &bom, nil
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+)
+
+const ApiFindingProject = "/finding/project"
+
+// Vulnerability sources as used by dependency track
+const (
+	SourceNVD      = "NVD"
+	SourceGitHub   = "GITHUB"
+	SourceOSV      = "OSV"
+	SourceOSSIndex = "OSSINDEX"
+	SourceSnyk     = "SNYK"
+	SourceVulnDB   = "VULNDB"
+	SourceInternal = "INTERNAL"
+)
+
+type FindingOptions struct {
+	Suppressed bool   `json:"suppressed,omitempty"`
+	Source     string `json:"source,omitempty"`
+}
+
+type FindingComponent struct {
+	UUID    string `json:"uuid,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Group   string `json:"group,omitempty"`
+	Version string `json:"version,omitempty"`
+	Purl    string `json:"purl,omitempty"`
+	Cpe     string `json:"cpe,omitempty"`
+	Project string `json:"project,omitempty"`
+}
+
+type FindingVulnerability struct {
+	UUID            string  `json:"uuid,omitempty"`
+	VulnId          string  `json:"vulnId,omitempty"`
+	Source          string  `json:"source,omitempty"`
+	Title           string  `json:"title,omitempty"`
+	Subtitle        string  `json:"subtitle,omitempty"`
+	Description     string  `json:"description,omitempty"`
+	Recommendation  string  `json:"recommendation,omitempty"`
+	Severity        string  `json:"severity,omitempty"`
+	SeverityRank    int     `json:"severityRank,omitempty"`
+	CvssV2BaseScore float64 `json:"cvssV2BaseScore,omitempty"`
+	CvssV3BaseScore float64 `json:"cvssV3BaseScore,omitempty"`
+	CweId           int     `json:"cweId,omitempty"`
+	CweName         string  `json:"cweName,omitempty"`
+}
+
+type FindingAnalysis struct {
+	State        string `json:"state,omitempty"`
+	IsSuppressed bool   `json:"isSuppressed,omitempty"`
+}
+
+type FindingAttribution struct {
+	AnalyzerIdentity    string `json:"analyzerIdentity,omitempty"`
+	AttributedOn        int64  `json:"attributedOn,omitempty"`
+	AlternateIdentifier string `json:"alternateIdentifier,omitempty"`
+	ReferenceUrl        string `json:"referenceUrl,omitempty"`
+}
+
+// Finding joins a project component with one of its vulnerabilities and the audit state of the pair.
+type Finding struct {
+	Component     FindingComponent     `json:"component"`
+	Vulnerability FindingVulnerability `json:"vulnerability"`
+	Analysis      FindingAnalysis      `json:"analysis"`
+	Attribution   FindingAttribution   `json:"attribution"`
+	Matrix        string               `json:"matrix,omitempty"`
+}
+
+type FindingList []Finding
+
+func (depClient *DepTrackClient) GetFindingsContext(ctx context.Context, project_uuid string, opts *FindingOptions) (FindingList, error) {
+	var finding_list FindingList
+	var params interface{}
+	if opts != nil {
+		params = opts
+	}
+	if err := depClient.GetJsonWithParamsContext(ctx, ApiFindingProject+"/"+project_uuid, params, &finding_list); err != nil {
+		return nil, err
+	}
+	return finding_list, nil
+}
+
+func (depClient *DepTrackClient) GetFindings(project_uuid string, opts *FindingOptions) (FindingList, error) {
+	return depClient.GetFindingsContext(context.Background(), project_uuid, opts)
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+const ApiComponentIntegrityMetadata = "/component/integritymetadata"
+
+// IntegrityMetadata is the repository metadata of a single component version, as fetched by the server integrity analysis.
+type IntegrityMetadata struct {
+	Purl          string `json:"purl,omitempty"`
+	Md5           string `json:"md5,omitempty"`
+	Sha1          string `json:"sha1,omitempty"`
+	Sha256        string `json:"sha256,omitempty"`
+	Sha512        string `json:"sha512,omitempty"`
+	Status        string `json:"status,omitempty"`
+	RepositoryUrl string `json:"repositoryUrl,omitempty"`
+	// PublishedAt and LastFetch are unix milliseconds
+	PublishedAt int64 `json:"publishedAt,omitempty"`
+	LastFetch   int64 `json:"lastFetch,omitempty"`
+}
+
+// PublishedTime is the release date of the version, zero when the repository does not report it.
+func (metadata *IntegrityMetadata) PublishedTime() time.Time {
+	return millisTime(metadata.PublishedAt)
+}
+
+// GetIntegrityMetadataContext looks up the repository metadata of the purl version,
+// the server only has it for components it already analyzed.
+func (depClient *DepTrackClient) GetIntegrityMetadataContext(ctx context.Context, PURL string) (*IntegrityMetadata, error) {
+	if err := depClient.requireContext(ctx, CapabilityIntegrityMetadata); err != nil {
+		return nil, err
+	}
+	var metadata IntegrityMetadata
+	if err := depClient.GetJsonWithParamsContext(ctx, ApiComponentIntegrityMetadata, LatestVersionParams{Purl: PURL}, &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+func (depClient *DepTrackClient) GetIntegrityMetadata(PURL string) (*IntegrityMetadata, error) {
+	return depClient.GetIntegrityMetadataContext(context.Background(), PURL)
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// CreateInternalVulnerabilityContext creates an INTERNAL source vulnerability,
+// the server generates the vuln id when it is not set and computes the scores from the cvss vectors.
+func (depClient *DepTrackClient) CreateInternalVulnerabilityContext(ctx context.Context, vulnraibility *Vulnraibility) (*Vulnraibility, error) {
+	internal := *vulnraibility
+	internal.Source = SourceInternal
+
+	var created Vulnraibility
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiVulnerability, &internal, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreateInternalVulnerability(vulnraibility *Vulnraibility) (*Vulnraibility, error) {
+	return depClient.CreateInternalVulnerabilityContext(context.Background(), vulnraibility)
+}
+
+// UpdateInternalVulnerabilityContext replaces the internal vulnerability identified by its UUID.
+func (depClient *DepTrackClient) UpdateInternalVulnerabilityContext(ctx context.Context, vulnraibility *Vulnraibility) (*Vulnraibility, error) {
+	if vulnraibility.UUID == "" {
+		return nil, errors.New("internal vulnerability update requires a uuid")
+	}
+	internal := *vulnraibility
+	internal.Source = SourceInternal
+
+	var updated Vulnraibility
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiVulnerability, &internal, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdateInternalVulnerability(vulnraibility *Vulnraibility) (*Vulnraibility, error) {
+	return depClient.UpdateInternalVulnerabilityContext(context.Background(), vulnraibility)
+}
+
+func (depClient *DepTrackClient) DeleteInternalVulnerabilityContext(ctx context.Context, uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, ApiVulnerability+"/"+uuid, nil, nil)
+}
+
+func (depClient *DepTrackClient) DeleteInternalVulnerability(uuid string) error {
+	return depClient.DeleteInternalVulnerabilityContext(context.Background(), uuid)
+}
+
+func vulnerabilityComponentApi(vuln_uuid string, component_uuid string) string {
+	return ApiVulnerability + "/" + vuln_uuid + "/component/" + component_uuid
+}
+
+// AssignVulnerabilityContext marks the component as affected by the vulnerability.
+func (depClient *DepTrackClient) AssignVulnerabilityContext(ctx context.Context, vuln_uuid string, component_uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodPost, vulnerabilityComponentApi(vuln_uuid, component_uuid), nil, nil)
+}
+
+func (depClient *DepTrackClient) AssignVulnerability(vuln_uuid string, component_uuid string) error {
+	return depClient.AssignVulnerabilityContext(context.Background(), vuln_uuid, component_uuid)
+}
+
+func (depClient *DepTrackClient) UnassignVulnerabilityContext(ctx context.Context, vuln_uuid string, component_uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, vulnerabilityComponentApi(vuln_uuid, component_uuid), nil, nil)
+}
+
+func (depClient *DepTrackClient) UnassignVulnerability(vuln_uuid string, component_uuid string) error {
+	return depClient.UnassignVulnerabilityContext(context.Background(), vuln_uuid, component_uuid)
+}
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const (
+	ApiLicense        = "/license"
+	ApiLicenseConcise = "/license/concise"
+	ApiLicenseGroup   = "/licenseGroup"
+)
+
+type License struct {
+	UUID                  string   `json:"uuid,omitempty"`
+	LicenseId             string   `json:"licenseId,omitempty"`
+	Name                  string   `json:"name,omitempty"`
+	LicenseText           string   `json:"licenseText,omitempty"`
+	StandardLicenseHeader string   `json:"standardLicenseHeader,omitempty"`
+	LicenseComments       string   `json:"licenseComments,omitempty"`
+	IsOsiApproved         bool     `json:"isOsiApproved"`
+	IsFsfLibre            bool     `json:"isFsfLibre"`
+	IsDeprecatedLicenseId bool     `json:"isDeprecatedLicenseId"`
+	IsCustomLicense       bool     `json:"isCustomLicense"`
+	SeeAlso               []string `json:"seeAlso,omitempty"`
+}
+
+type LicenseList []License
+
+type LicenseGroup struct {
+	UUID       string      `json:"uuid,omitempty"`
+	Name       string      `json:"name"`
+	RiskWeight int         `json:"riskWeight"`
+	Licenses   LicenseList `json:"licenses,omitempty"`
+}
+
+type LicenseGroupList []LicenseGroup
+
+// FindByName returns the license group named name, nil if there is none.
+func (list LicenseGroupList) FindByName(name string) *LicenseGroup {
+	for i := range list {
+		if list[i].Name == name {
+			return &list[i]
+		}
+	}
+	return nil
+}
+
+// GetLicensesContext lists every license without the license texts.
+func (depClient *DepTrackClient) GetLicensesContext(ctx context.Context) (LicenseList, error) {
+	var license_list LicenseList
+	if err := depClient.GetJsonContext(ctx, ApiLicenseConcise, &license_list); err != nil {
+		return nil, err
+	}
+	return license_list, nil
+}
+
+func (depClient *DepTrackClient) GetLicenses() (LicenseList, error) {
+	return depClient.GetLicensesContext(context.Background())
+}
+
+// GetLicenseContext looks up a license by its SPDX id, or the id of a custom license.
+func (depClient *DepTrackClient) GetLicenseContext(ctx context.Context, license_id string) (*License, error) {
+	var license License
+	if err := depClient.GetJsonContext(ctx, ApiLicense+"/"+url.PathEscape(license_id), &license); err != nil {
+		return nil, err
+	}
+	return &license, nil
+}
+
+func (depClient *DepTrackClient) GetLicense(license_id string) (*License, error) {
+	return depClient.GetLicenseContext(context.Background(), license_id)
+}
+
+// CreateLicenseContext creates a custom license, SPDX licenses are managed by the server.
+func (depClient *DepTrackClient) CreateLicenseContext(ctx context.Context, license *License) (*License, error) {
+	var created License
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiLicense, license, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreateLicense(license *License) (*License, error) {
+	return depClient.CreateLicenseContext(context.Background(), license)
+}
+
+// DeleteLicenseContext deletes a custom license.
+func (depClient *DepTrackClient) DeleteLicenseContext(ctx context.Context, license_id string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, ApiLicense+"/"+url.PathEscape(license_id), nil, nil)
+}
+
+func (depClient *DepTrackClient) DeleteLicense(license_id string) error {
+	return depClient.DeleteLicenseContext(context.Background(), license_id)
+}
+
+func licenseGroupApi(uuid string) string {
+	return ApiLicenseGroup + "/" + uuid
+}
+
+func (depClient *DepTrackClient) GetLicenseGroupsContext(ctx context.Context) (LicenseGroupList, error) {
+	var group_list LicenseGroupList
+	if err := depClient.CollectAllContext(ctx, ApiLicenseGroup, nil, DefaultPageSize, &group_list); err != nil {
+		return nil, err
+	}
+	return group_list, nil
+}
+
+func (depClient *DepTrackClient) GetLicenseGroups() (LicenseGroupList, error) {
+	return depClient.GetLicenseGroupsContext(context.Background())
+}
+
+func (depClient *DepTrackClient) GetLicenseGroupContext(ctx context.Context, uuid string) (*LicenseGroup, error) {
+	var group LicenseGroup
+	if err := depClient.GetJsonContext(ctx, licenseGroupApi(uuid), &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (depClient *DepTrackClient) GetLicenseGroup(uuid string) (*LicenseGroup, error) {
+	return depClient.GetLicenseGroupContext(context.Background(), uuid)
+}
+
+func (depClient *DepTrackClient) CreateLicenseGroupContext(ctx context.Context, group *LicenseGroup) (*LicenseGroup, error) {
+	var created LicenseGroup
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiLicenseGroup, group, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreateLicenseGroup(group *LicenseGroup) (*LicenseGroup, error) {
+	return depClient.CreateLicenseGroupContext(context.Background(), group)
+}
+
+// UpdateLicenseGroupContext updates the name and risk weight, licenses are managed with Add/RemoveLicenseGroupLicense.
+func (depClient *DepTrackClient) UpdateLicenseGroupContext(ctx context.Context, group *LicenseGroup) (*LicenseGroup, error) {
+	var updated LicenseGroup
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiLicenseGroup, group, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdateLicenseGroup(group *LicenseGroup) (*LicenseGroup, error) {
+	return depClient.UpdateLicenseGroupContext(context.Background(), group)
+}
+
+func (depClient *DepTrackClient) DeleteLicenseGroupContext(ctx context.Context, uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, licenseGroupApi(uuid), nil, nil)
+}
+
+func (depClient *DepTrackClient) DeleteLicenseGroup(uuid string) error {
+	return depClient.DeleteLicenseGroupContext(context.Background(), uuid)
+}
+
+func (depClient *DepTrackClient) AddLicenseGroupLicenseContext(ctx context.Context, group_uuid string, license_uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodPost, licenseGroupApi(group_uuid)+"/license/"+license_uuid, nil, nil)
+}
+
+func (depClient *DepTrackClient) AddLicenseGroupLicense(group_uuid string, license_uuid string) error {
+	return depClient.AddLicenseGroupLicenseContext(context.Background(), group_uuid, license_uuid)
+}
+
+func (depClient *DepTrackClient) RemoveLicenseGroupLicenseContext(ctx context.Context, group_uuid string, license_uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, licenseGroupApi(group_uuid)+"/license/"+license_uuid, nil, nil)
+}
+
+func (depClient *DepTrackClient) RemoveLicenseGroupLicense(group_uuid string, license_uuid string) error {
+	return depClient.RemoveLicenseGroupLicenseContext(context.Background(), group_uuid, license_uuid)
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	ApiMetricsPortfolio = "/metrics/portfolio"
+	ApiMetricsProject   = "/metrics/project"
+	MetricsCurrent      = "current"
+	MetricsRefresh      = "refresh"
+	MetricsDays         = "days"
+)
+
+// ProjectMetrics is a metrics snapshot of a single project, occurrences are epoch milliseconds.
+type ProjectMetrics struct {
+	Critical                             int     `json:"critical,omitempty"`
+	High                                 int     `json:"high,omitempty"`
+	Medium                               int     `json:"medium,omitempty"`
+	Low                                  int     `json:"low,omitempty"`
+	Unassigned                           int     `json:"unassigned,omitempty"`
+	Vulnerabilities                      int     `json:"vulnerabilities,omitempty"`
+	VulnerableComponents                 int     `json:"vulnerableComponents,omitempty"`
+	Components                           int     `json:"components,omitempty"`
+	Suppressed                           int     `json:"suppressed,omitempty"`
+	FindingsTotal                        int     `json:"findingsTotal,omitempty"`
+	FindingsAudited                      int     `json:"findingsAudited,omitempty"`
+	FindingsUnaudited                    int     `json:"findingsUnaudited,omitempty"`
+	InheritedRiskScore                   float64 `json:"inheritedRiskScore,omitempty"`
+	PolicyViolationsFail                 int     `json:"policyViolationsFail,omitempty"`
+	PolicyViolationsWarn                 int     `json:"policyViolationsWarn,omitempty"`
+	PolicyViolationsInfo                 int     `json:"policyViolationsInfo,omitempty"`
+	PolicyViolationsTotal                int     `json:"policyViolationsTotal,omitempty"`
+	PolicyViolationsAudited              int     `json:"policyViolationsAudited,omitempty"`
+	PolicyViolationsUnaudited            int     `json:"policyViolationsUnaudited,omitempty"`
+	PolicyViolationsSecurityTotal        int     `json:"policyViolationsSecurityTotal,omitempty"`
+	PolicyViolationsSecurityAudited      int     `json:"policyViolationsSecurityAudited,omitempty"`
+	PolicyViolationsSecurityUnaudited    int     `json:"policyViolationsSecurityUnaudited,omitempty"`
+	PolicyViolationsLicenseTotal         int     `json:"policyViolationsLicenseTotal,omitempty"`
+	PolicyViolationsLicenseAudited       int     `json:"policyViolationsLicenseAudited,omitempty"`
+	PolicyViolationsLicenseUnaudited     int     `json:"policyViolationsLicenseUnaudited,omitempty"`
+	PolicyViolationsOperationalTotal     int     `json:"policyViolationsOperationalTotal,omitempty"`
+	PolicyViolationsOperationalAudited   int     `json:"policyViolationsOperationalAudited,omitempty"`
+	PolicyViolationsOperationalUnaudited int     `json:"policyViolationsOperationalUnaudited,omitempty"`
+	FirstOccurrence                      int64   `json:"firstOccurrence,omitempty"`
+	LastOccurrence                       int64   `json:"lastOccurrence,omitempty"`
+	// Deprecated: kept for Metrics_stat callers, the server reports the component count as Components.
+	Component int `json:"component,omitempty"`
+}
+
+// PortfolioMetrics is a metrics snapshot across all projects.
+type PortfolioMetrics struct {
+	ProjectMetrics
+	Projects           int `json:"projects,omitempty"`
+	VulnerableProjects int `json:"vulnerableProjects,omitempty"`
+}
+
+type ProjectMetricsList []ProjectMetrics
+type PortfolioMetricsList []PortfolioMetrics
+
+// AuditProgress is the audited share of the findings between 0 and 1, one when there are no findings.
+func (m ProjectMetrics) AuditProgress() float64 {
+	if m.FindingsTotal == 0 {
+		return 1
+	}
+	return float64(m.FindingsAudited) / float64(m.FindingsTotal)
+}
+
+func (m ProjectMetrics) LastOccurrenceTime() time.Time {
+	return time.Unix(0, m.LastOccurrence*int64(time.Millisecond))
+}
+
+func projectMetricsApi(uuid string) string {
+	return ApiMetricsProject + "/" + uuid
+}
+
+func (depClient *DepTrackClient) GetProjectMetricsContext(ctx context.Context, project_uuid string) (*ProjectMetrics, error) {
+	var metrics ProjectMetrics
+	if err := depClient.GetJsonContext(ctx, projectMetricsApi(project_uuid)+"/"+MetricsCurrent, &metrics); err != nil {
+		return nil, err
+	}
+	return &metrics, nil
+}
+
+func (depClient *DepTrackClient) GetProjectMetrics(project_uuid string) (*ProjectMetrics, error) {
+	return depClient.GetProjectMetricsContext(context.Background(), project_uuid)
+}
+
+// GetProjectMetricsHistoryContext returns the project metrics of the last days, oldest first.
+func (depClient *DepTrackClient) GetProjectMetricsHistoryContext(ctx context.Context, project_uuid string, days int) (ProjectMetricsList, error) {
+	var metrics_list ProjectMetricsList
+	full_api := projectMetricsApi(project_uuid) + "/" + MetricsDays + "/" + strconv.Itoa(days)
+	if err := depClient.GetJsonContext(ctx, full_api, &metrics_list); err != nil {
+		return nil, err
+	}
+	return metrics_list, nil
+}
+
+func (depClient *DepTrackClient) GetProjectMetricsHistory(project_uuid string, days int) (ProjectMetricsList, error) {
+	return depClient.GetProjectMetricsHistoryContext(context.Background(), project_uuid, days)
+}
+
+// RefreshProjectMetricsContext requests a metrics update, the server recalculates asynchronously.
+func (depClient *DepTrackClient) RefreshProjectMetricsContext(ctx context.Context, project_uuid string) error {
+	resp, err := depClient.DoContext(ctx, http.MethodGet, projectMetricsApi(project_uuid)+"/"+MetricsRefresh, nil, "", nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (depClient *DepTrackClient) RefreshProjectMetrics(project_uuid string) error {
+	return depClient.RefreshProjectMetricsContext(context.Background(), project_uuid)
+}
+
+func (depClient *DepTrackClient) GetPortfolioMetricsContext(ctx context.Context) (*PortfolioMetrics, error) {
+	var metrics PortfolioMetrics
+	if err := depClient.GetJsonContext(ctx, ApiMetricsPortfolio+"/"+MetricsCurrent, &metrics); err != nil {
+		return nil, err
+	}
+	return &metrics, nil
+}
+
+func (depClient *DepTrackClient) GetPortfolioMetrics() (*PortfolioMetrics, error) {
+	return depClient.GetPortfolioMetricsContext(context.Background())
+}
+
+// GetPortfolioMetricsHistoryContext returns the portfolio metrics of the last days, oldest first.
+func (depClient *DepTrackClient) GetPortfolioMetricsHistoryContext(ctx context.Context, days int) (PortfolioMetricsList, error) {
+	var metrics_list PortfolioMetricsList
+	full_api := ApiMetricsPortfolio + "/" + strconv.Itoa(days) + "/" + MetricsDays
+	if err := depClient.GetJsonContext(ctx, full_api, &metrics_list); err != nil {
+		return nil, err
+	}
+	return metrics_list, nil
+}
+
+func (depClient *DepTrackClient) GetPortfolioMetricsHistory(days int) (PortfolioMetricsList, error) {
+	return depClient.GetPortfolioMetricsHistoryContext(context.Background(), days)
+}
+
+func (depClient *DepTrackClient) RefreshPortfolioMetricsContext(ctx context.Context) error {
+	resp, err := depClient.DoContext(ctx, http.MethodGet, ApiMetricsPortfolio+"/"+MetricsRefresh, nil, "", nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (depClient *DepTrackClient) RefreshPortfolioMetrics() error {
+	return depClient.RefreshPortfolioMetricsContext(context.Background())
+}
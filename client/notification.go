@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	ApiNotificationRule      = "/notification/rule"
+	ApiNotificationPublisher = "/notification/publisher"
+)
+
+// Notification scopes, levels and groups as used by dependency track
+const (
+	NotificationScopePortfolio = "PORTFOLIO"
+	NotificationScopeSystem    = "SYSTEM"
+
+	NotificationLevelInformational = "INFORMATIONAL"
+	NotificationLevelWarning       = "WARNING"
+	NotificationLevelError         = "ERROR"
+
+	NotificationGroupNewVulnerability        = "NEW_VULNERABILITY"
+	NotificationGroupNewVulnerableDependency = "NEW_VULNERABLE_DEPENDENCY"
+	NotificationGroupBomConsumed             = "BOM_CONSUMED"
+	NotificationGroupBomProcessed            = "BOM_PROCESSED"
+	NotificationGroupPolicyViolation         = "POLICY_VIOLATION"
+	NotificationGroupProjectAuditChange      = "PROJECT_AUDIT_CHANGE"
+	NotificationGroupVexConsumed             = "VEX_CONSUMED"
+	NotificationGroupVexProcessed            = "VEX_PROCESSED"
+
+	WebhookPublisherName = "Outbound Webhook"
+)
+
+type NotificationPublisher struct {
+	UUID             string `json:"uuid,omitempty"`
+	Name             string `json:"name"`
+	Description      string `json:"description,omitempty"`
+	PublisherClass   string `json:"publisherClass,omitempty"`
+	Template         string `json:"template,omitempty"`
+	TemplateMimeType string `json:"templateMimeType,omitempty"`
+	DefaultPublisher bool   `json:"defaultPublisher,omitempty"`
+}
+
+type NotificationPublisherList []NotificationPublisher
+
+type NotificationRule struct {
+	UUID              string                 `json:"uuid,omitempty"`
+	Name              string                 `json:"name"`
+	Enabled           bool                   `json:"enabled"`
+	NotifyChildren    bool                   `json:"notifyChildren"`
+	Scope             string                 `json:"scope,omitempty"`
+	NotificationLevel string                 `json:"notificationLevel,omitempty"`
+	NotifyOn          []string               `json:"notifyOn,omitempty"`
+	Publisher         *NotificationPublisher `json:"publisher,omitempty"`
+	PublisherConfig   string                 `json:"publisherConfig,omitempty"`
+	Projects          []ProjectRef           `json:"projects,omitempty"`
+}
+
+type NotificationRuleList []NotificationRule
+
+func (depClient *DepTrackClient) GetNotificationPublishersContext(ctx context.Context) (NotificationPublisherList, error) {
+	var publisher_list NotificationPublisherList
+	if err := depClient.GetJsonContext(ctx, ApiNotificationPublisher, &publisher_list); err != nil {
+		return nil, err
+	}
+	return publisher_list, nil
+}
+
+func (depClient *DepTrackClient) GetNotificationPublishers() (NotificationPublisherList, error) {
+	return depClient.GetNotificationPublishersContext(context.Background())
+}
+
+func (depClient *DepTrackClient) GetNotificationRulesContext(ctx context.Context) (NotificationRuleList, error) {
+	var rule_list NotificationRuleList
+	if err := depClient.CollectAllContext(ctx, ApiNotificationRule, nil, DefaultPageSize, &rule_list); err != nil {
+		return nil, err
+	}
+	return rule_list, nil
+}
+
+func (depClient *DepTrackClient) GetNotificationRules() (NotificationRuleList, error) {
+	return depClient.GetNotificationRulesContext(context.Background())
+}
+
+// CreateNotificationRuleContext creates the rule from its name, scope, level and publisher,
+// the remaining fields must be set with UpdateNotificationRuleContext.
+func (depClient *DepTrackClient) CreateNotificationRuleContext(ctx context.Context, rule *NotificationRule) (*NotificationRule, error) {
+	var created NotificationRule
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiNotificationRule, rule, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreateNotificationRule(rule *NotificationRule) (*NotificationRule, error) {
+	return depClient.CreateNotificationRuleContext(context.Background(), rule)
+}
+
+func (depClient *DepTrackClient) UpdateNotificationRuleContext(ctx context.Context, rule *NotificationRule) (*NotificationRule, error) {
+	var updated NotificationRule
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiNotificationRule, rule, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdateNotificationRule(rule *NotificationRule) (*NotificationRule, error) {
+	return depClient.UpdateNotificationRuleContext(context.Background(), rule)
+}
+
+func (depClient *DepTrackClient) DeleteNotificationRuleContext(ctx context.Context, uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, ApiNotificationRule, NotificationRule{UUID: uuid}, nil)
+}
+
+func (depClient *DepTrackClient) DeleteNotificationRule(uuid string) error {
+	return depClient.DeleteNotificationRuleContext(context.Background(), uuid)
+}
+
+func (depClient *DepTrackClient) CreateNotificationPublisherContext(ctx context.Context, publisher *NotificationPublisher) (*NotificationPublisher, error) {
+	var created NotificationPublisher
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiNotificationPublisher, publisher, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreateNotificationPublisher(publisher *NotificationPublisher) (*NotificationPublisher, error) {
+	return depClient.CreateNotificationPublisherContext(context.Background(), publisher)
+}
+
+func (depClient *DepTrackClient) UpdateNotificationPublisherContext(ctx context.Context, publisher *NotificationPublisher) (*NotificationPublisher, error) {
+	var updated NotificationPublisher
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiNotificationPublisher, publisher, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdateNotificationPublisher(publisher *NotificationPublisher) (*NotificationPublisher, error) {
+	return depClient.UpdateNotificationPublisherContext(context.Background(), publisher)
+}
+
+// DeleteNotificationPublisherContext deletes a user defined publisher, default publishers can not be deleted.
+func (depClient *DepTrackClient) DeleteNotificationPublisherContext(ctx context.Context, uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, ApiNotificationPublisher+"/"+uuid, nil, nil)
+}
+
+func (depClient *DepTrackClient) DeleteNotificationPublisher(uuid string) error {
+	return depClient.DeleteNotificationPublisherContext(context.Background(), uuid)
+}
+
+func notificationRuleProjectApi(rule_uuid string, project_uuid string) string {
+	return ApiNotificationRule + "/" + rule_uuid + "/project/" + project_uuid
+}
+
+// AddNotificationRuleProjectContext limits a portfolio rule to the project, a rule without projects applies to all of them.
+func (depClient *DepTrackClient) AddNotificationRuleProjectContext(ctx context.Context, rule_uuid string, project_uuid string) (*NotificationRule, error) {
+	var rule NotificationRule
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, notificationRuleProjectApi(rule_uuid, project_uuid), nil, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (depClient *DepTrackClient) AddNotificationRuleProject(rule_uuid string, project_uuid string) (*NotificationRule, error) {
+	return depClient.AddNotificationRuleProjectContext(context.Background(), rule_uuid, project_uuid)
+}
+
+func (depClient *DepTrackClient) RemoveNotificationRuleProjectContext(ctx context.Context, rule_uuid string, project_uuid string) (*NotificationRule, error) {
+	var rule NotificationRule
+	if err := depClient.SendJsonContext(ctx, http.MethodDelete, notificationRuleProjectApi(rule_uuid, project_uuid), nil, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (depClient *DepTrackClient) RemoveNotificationRuleProject(rule_uuid string, project_uuid string) (*NotificationRule, error) {
+	return depClient.RemoveNotificationRuleProjectContext(context.Background(), rule_uuid, project_uuid)
+}
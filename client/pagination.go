@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+const (
+	TotalCountHeader = "X-Total-Count"
+	DefaultPageSize  = 100
+	OffsetField      = "offset"
+	LimitField       = "limit"
+)
+
+// Paginator walks a list endpoint page by page following the X-Total-Count header.
+type Paginator struct {
+	depClient *DepTrackClient
+	api       string
+	params    interface{}
+	page_size int
+	offset    int
+	total     int
+	done      bool
+}
+
+// NewPaginator creates a paginator for api, params are sent with every page request.
+// A page_size below one uses DefaultPageSize.
+func (depClient *DepTrackClient) NewPaginator(api string, params interface{}, page_size int) *Paginator {
+	if page_size < 1 {
+		page_size = DefaultPageSize
+	}
+	return &Paginator{depClient: depClient, api: api, params: params, page_size: page_size, total: -1}
+}
+
+// Total is the item count reported by the server, -1 until a page was read or if the server does not report it.
+func (p *Paginator) Total() int {
+	return p.total
+}
+
+// NextPage decodes the next page into dst, a pointer to a slice.
+// It returns false once every page was read, in which case dst is left untouched.
+func (p *Paginator) NextPage(ctx context.Context, dst interface{}) (bool, error) {
+	if p.done {
+		return false, nil
+	}
+
+	values, err := encodeParams(p.params)
+	if err != nil {
+		return false, err
+	}
+	values.Set(OffsetField, strconv.Itoa(p.offset))
+	values.Set(LimitField, strconv.Itoa(p.page_size))
+
+	resp, err := p.depClient.DoContext(ctx, http.MethodGet, p.api, values, "", nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return false, err
+	}
+
+	page_len := reflect.Indirect(reflect.ValueOf(dst)).Len()
+	p.offset += page_len
+	if total, err := strconv.Atoi(resp.Header.Get(TotalCountHeader)); err == nil {
+		p.total = total
+		p.done = p.offset >= total || page_len == 0
+	} else {
+		p.done = page_len < p.page_size
+	}
+	return true, nil
+}
+
+// ForEachContext streams every item of a list endpoint to fn, page by page.
+func (depClient *DepTrackClient) ForEachContext(ctx context.Context, api string, params interface{}, page_size int, fn func(item json.RawMessage) error) error {
+	paginator := depClient.NewPaginator(api, params, page_size)
+	for {
+		var page []json.RawMessage
+		ok, err := paginator.NextPage(ctx, &page)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		for _, item := range page {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// StreamContext sends every item of a list endpoint on the returned channel.
+// The error channel receives at most one error and both channels are closed once the walk is done.
+func (depClient *DepTrackClient) StreamContext(ctx context.Context, api string, params interface{}, page_size int) (<-chan json.RawMessage, <-chan error) {
+	items := make(chan json.RawMessage)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(items)
+		defer close(errs)
+		err := depClient.ForEachContext(ctx, api, params, page_size, func(item json.RawMessage) error {
+			select {
+			case items <- item:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errs <- err
+		}
+	}()
+	return items, errs
+}
+
+// CollectAllContext reads every page of a list endpoint into dst, a pointer to a slice.
+func (depClient *DepTrackClient) CollectAllContext(ctx context.Context, api string, params interface{}, page_size int, dst interface{}) error {
+	dst_value := reflect.ValueOf(dst)
+	if dst_value.Kind() != reflect.Ptr || dst_value.Elem().Kind() != reflect.Slice {
+		return errors.New("collect all destination must be a pointer to a slice")
+	}
+
+	all := dst_value.Elem()
+	paginator := depClient.NewPaginator(api, params, page_size)
+	for {
+		page := reflect.New(all.Type())
+		ok, err := paginator.NextPage(ctx, page.Interface())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		all.Set(reflect.AppendSlice(all, page.Elem()))
+	}
+}
+
+// ForEachComponentByProjectUUIDContext streams every component of a project to fn.
+func (depClient *DepTrackClient) ForEachComponentByProjectUUIDContext(ctx context.Context, uuid string, page_size int, fn func(Component) error) error {
+	return depClient.ForEachContext(ctx, ApiComponentProject+"/"+uuid, nil, page_size, func(item json.RawMessage) error {
+		var component Component
+		if err := json.Unmarshal(item, &component); err != nil {
+			return err
+		}
+		return fn(component)
+	})
+}
+
+func (depClient *DepTrackClient) GetAllComponentsByProjectUUIDContext(ctx context.Context, uuid string) (ComponentList, error) {
+	var component_list ComponentList
+	if err := depClient.CollectAllContext(ctx, ApiComponentProject+"/"+uuid, nil, DefaultPageSize, &component_list); err != nil {
+		return nil, err
+	}
+	return component_list, nil
+}
+
+func (depClient *DepTrackClient) GetAllComponentsByProjectUUID(uuid string) (ComponentList, error) {
+	return depClient.GetAllComponentsByProjectUUIDContext(context.Background(), uuid)
+}
+
+// GetAllComponentsIdentityContext reads every matching component, the pagination in params is ignored.
+func (depClient *DepTrackClient) GetAllComponentsIdentityContext(ctx context.Context, params GetComponentsIdentityParams) (ComponentList, error) {
+	var component_list ComponentList
+	params.PaginationParams = PaginationParams{}
+	if err := depClient.CollectAllContext(ctx, ApiComponentIdentity, params, DefaultPageSize, &component_list); err != nil {
+		return nil, err
+	}
+	return component_list, nil
+}
+
+func (depClient *DepTrackClient) GetAllComponentsIdentity(params GetComponentsIdentityParams) (ComponentList, error) {
+	return depClient.GetAllComponentsIdentityContext(context.Background(), params)
+}
+
+func (depClient *DepTrackClient) GetAllVulnerabilityComponenetByUUIDContext(ctx context.Context, uuid string, isSupported bool) (VulnraibilityList, error) {
+	var vulnraibilityList VulnraibilityList
+	params := GetVulnerabilityByUUIDParams{Suppressed: isSupported}
+	if err := depClient.CollectAllContext(ctx, ApiVulnrabilityComponent+"/"+uuid, params, DefaultPageSize, &vulnraibilityList); err != nil {
+		return nil, err
+	}
+	return vulnraibilityList, nil
+}
+
+func (depClient *DepTrackClient) GetAllVulnerabilityComponenetByUUID(uuid string, isSupported bool) (VulnraibilityList, error) {
+	return depClient.GetAllVulnerabilityComponenetByUUIDContext(context.Background(), uuid, isSupported)
+}
@@ -0,0 +1,297 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	ApiPolicy           = "/policy"
+	ApiPolicyCondition  = "/policy/condition"
+	ApiViolationProject = "/violation/project"
+)
+
+// Policy operators, violation states and violation types as used by dependency track
+const (
+	PolicyOperatorAll = "ALL"
+	PolicyOperatorAny = "ANY"
+
+	ViolationStateInfo = "INFO"
+	ViolationStateWarn = "WARN"
+	ViolationStateFail = "FAIL"
+
+	ViolationTypeLicense     = "LICENSE"
+	ViolationTypeSecurity    = "SECURITY"
+	ViolationTypeOperational = "OPERATIONAL"
+
+	ViolationAnalysisApproved = "APPROVED"
+	ViolationAnalysisRejected = "REJECTED"
+	ViolationAnalysisNotSet   = "NOT_SET"
+)
+
+type PolicyCondition struct {
+	UUID     string  `json:"uuid,omitempty"`
+	Subject  string  `json:"subject,omitempty"`
+	Operator string  `json:"operator,omitempty"`
+	Value    string  `json:"value,omitempty"`
+	Policy   *Policy `json:"policy,omitempty"`
+}
+
+type Policy struct {
+	UUID             string            `json:"uuid,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	Operator         string            `json:"operator,omitempty"`
+	ViolationState   string            `json:"violationState,omitempty"`
+	PolicyConditions []PolicyCondition `json:"policyConditions,omitempty"`
+	Projects         []ProjectRef      `json:"projects,omitempty"`
+	Tags             []Tag             `json:"tags,omitempty"`
+	IncludeChildren  bool              `json:"includeChildren,omitempty"`
+}
+
+type PolicyList []Policy
+
+type ViolationAnalysis struct {
+	State        string `json:"analysisState,omitempty"`
+	IsSuppressed bool   `json:"isSuppressed,omitempty"`
+}
+
+type PolicyViolation struct {
+	UUID            string             `json:"uuid,omitempty"`
+	Type            string             `json:"type,omitempty"`
+	Timestamp       int64              `json:"timestamp,omitempty"`
+	Component       FindingComponent   `json:"component"`
+	Project         *ProjectRef        `json:"project,omitempty"`
+	PolicyCondition PolicyCondition    `json:"policyCondition"`
+	Analysis        *ViolationAnalysis `json:"analysis,omitempty"`
+}
+
+type PolicyViolationList []PolicyViolation
+
+type GetViolationsParams struct {
+	Suppressed bool `json:"suppressed,omitempty"`
+}
+
+func policyApi(uuid string) string {
+	return ApiPolicy + "/" + uuid
+}
+
+func (depClient *DepTrackClient) GetPoliciesContext(ctx context.Context) (PolicyList, error) {
+	var policy_list PolicyList
+	if err := depClient.CollectAllContext(ctx, ApiPolicy, nil, DefaultPageSize, &policy_list); err != nil {
+		return nil, err
+	}
+	return policy_list, nil
+}
+
+func (depClient *DepTrackClient) GetPolicies() (PolicyList, error) {
+	return depClient.GetPoliciesContext(context.Background())
+}
+
+func (depClient *DepTrackClient) GetPolicyContext(ctx context.Context, uuid string) (*Policy, error) {
+	var policy Policy
+	if err := depClient.GetJsonContext(ctx, policyApi(uuid), &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (depClient *DepTrackClient) GetPolicy(uuid string) (*Policy, error) {
+	return depClient.GetPolicyContext(context.Background(), uuid)
+}
+
+func (depClient *DepTrackClient) CreatePolicyContext(ctx context.Context, policy *Policy) (*Policy, error) {
+	var created Policy
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiPolicy, policy, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreatePolicy(policy *Policy) (*Policy, error) {
+	return depClient.CreatePolicyContext(context.Background(), policy)
+}
+
+func (depClient *DepTrackClient) UpdatePolicyContext(ctx context.Context, policy *Policy) (*Policy, error) {
+	var updated Policy
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiPolicy, policy, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdatePolicy(policy *Policy) (*Policy, error) {
+	return depClient.UpdatePolicyContext(context.Background(), policy)
+}
+
+func (depClient *DepTrackClient) DeletePolicyContext(ctx context.Context, uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, policyApi(uuid), nil, nil)
+}
+
+func (depClient *DepTrackClient) DeletePolicy(uuid string) error {
+	return depClient.DeletePolicyContext(context.Background(), uuid)
+}
+
+func (depClient *DepTrackClient) CreatePolicyConditionContext(ctx context.Context, policy_uuid string, condition *PolicyCondition) (*PolicyCondition, error) {
+	var created PolicyCondition
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, policyApi(policy_uuid)+"/condition", condition, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreatePolicyCondition(policy_uuid string, condition *PolicyCondition) (*PolicyCondition, error) {
+	return depClient.CreatePolicyConditionContext(context.Background(), policy_uuid, condition)
+}
+
+func (depClient *DepTrackClient) UpdatePolicyConditionContext(ctx context.Context, condition *PolicyCondition) (*PolicyCondition, error) {
+	var updated PolicyCondition
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiPolicyCondition, condition, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdatePolicyCondition(condition *PolicyCondition) (*PolicyCondition, error) {
+	return depClient.UpdatePolicyConditionContext(context.Background(), condition)
+}
+
+func (depClient *DepTrackClient) DeletePolicyConditionContext(ctx context.Context, condition_uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, ApiPolicyCondition+"/"+condition_uuid, nil, nil)
+}
+
+func (depClient *DepTrackClient) DeletePolicyCondition(condition_uuid string) error {
+	return depClient.DeletePolicyConditionContext(context.Background(), condition_uuid)
+}
+
+func (depClient *DepTrackClient) AddPolicyProjectContext(ctx context.Context, policy_uuid string, project_uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodPost, policyApi(policy_uuid)+"/project/"+project_uuid, nil, nil)
+}
+
+func (depClient *DepTrackClient) AddPolicyProject(policy_uuid string, project_uuid string) error {
+	return depClient.AddPolicyProjectContext(context.Background(), policy_uuid, project_uuid)
+}
+
+func (depClient *DepTrackClient) RemovePolicyProjectContext(ctx context.Context, policy_uuid string, project_uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, policyApi(policy_uuid)+"/project/"+project_uuid, nil, nil)
+}
+
+func (depClient *DepTrackClient) RemovePolicyProject(policy_uuid string, project_uuid string) error {
+	return depClient.RemovePolicyProjectContext(context.Background(), policy_uuid, project_uuid)
+}
+
+func (depClient *DepTrackClient) AddPolicyTagContext(ctx context.Context, policy_uuid string, tag string) error {
+	return depClient.SendJsonContext(ctx, http.MethodPost, policyApi(policy_uuid)+"/tag/"+url.PathEscape(tag), nil, nil)
+}
+
+func (depClient *DepTrackClient) AddPolicyTag(policy_uuid string, tag string) error {
+	return depClient.AddPolicyTagContext(context.Background(), policy_uuid, tag)
+}
+
+func (depClient *DepTrackClient) RemovePolicyTagContext(ctx context.Context, policy_uuid string, tag string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, policyApi(policy_uuid)+"/tag/"+url.PathEscape(tag), nil, nil)
+}
+
+func (depClient *DepTrackClient) RemovePolicyTag(policy_uuid string, tag string) error {
+	return depClient.RemovePolicyTagContext(context.Background(), policy_uuid, tag)
+}
+
+func (depClient *DepTrackClient) GetProjectViolationsContext(ctx context.Context, project_uuid string, suppressed bool) (PolicyViolationList, error) {
+	var violation_list PolicyViolationList
+	params := GetViolationsParams{Suppressed: suppressed}
+	if err := depClient.CollectAllContext(ctx, ApiViolationProject+"/"+project_uuid, params, DefaultPageSize, &violation_list); err != nil {
+		return nil, err
+	}
+	return violation_list, nil
+}
+
+func (depClient *DepTrackClient) GetProjectViolations(project_uuid string, suppressed bool) (PolicyViolationList, error) {
+	return depClient.GetProjectViolationsContext(context.Background(), project_uuid, suppressed)
+}
+
+type GateConfig struct {
+	// FailOn lists the policy violation states failing the gate, empty fails on FAIL only.
+	FailOn []string
+	// Types limits the gate to the listed violation types, empty checks every type.
+	Types []string
+	// IncludeSuppressed also counts suppressed and approved violations.
+	IncludeSuppressed bool
+}
+
+type GateReason struct {
+	Policy         string
+	ViolationState string
+	Type           string
+	Component      string
+	Condition      string
+}
+
+func (r GateReason) String() string {
+	return fmt.Sprintf("%s policy %s violated by %s, %s violation on %s", r.ViolationState, r.Policy, r.Component, r.Type, r.Condition)
+}
+
+type GateResult struct {
+	Passed  bool
+	Reasons []GateReason
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// EvaluateGateContext fails when the project has a policy violation in one of the cfg.FailOn states.
+func (depClient *DepTrackClient) EvaluateGateContext(ctx context.Context, project_uuid string, cfg GateConfig) (*GateResult, error) {
+	violation_list, err := depClient.GetProjectViolationsContext(ctx, project_uuid, cfg.IncludeSuppressed)
+	if err != nil {
+		return nil, err
+	}
+	return EvaluateViolations(violation_list, cfg), nil
+}
+
+func (depClient *DepTrackClient) EvaluateGate(project_uuid string, cfg GateConfig) (*GateResult, error) {
+	return depClient.EvaluateGateContext(context.Background(), project_uuid, cfg)
+}
+
+// EvaluateViolations applies the gate to an already fetched violation list.
+func EvaluateViolations(violation_list PolicyViolationList, cfg GateConfig) *GateResult {
+	fail_on := cfg.FailOn
+	if len(fail_on) == 0 {
+		fail_on = []string{ViolationStateFail}
+	}
+
+	result := &GateResult{Passed: true}
+	for _, violation := range violation_list {
+		if len(cfg.Types) != 0 && !containsString(cfg.Types, violation.Type) {
+			continue
+		}
+		if !cfg.IncludeSuppressed && violation.Analysis != nil &&
+			(violation.Analysis.IsSuppressed || violation.Analysis.State == ViolationAnalysisApproved) {
+			continue
+		}
+
+		condition := violation.PolicyCondition
+		if condition.Policy == nil || !containsString(fail_on, condition.Policy.ViolationState) {
+			continue
+		}
+
+		component := violation.Component.Purl
+		if component == "" {
+			component = violation.Component.Name + "@" + violation.Component.Version
+		}
+		result.Passed = false
+		result.Reasons = append(result.Reasons, GateReason{
+			Policy:         condition.Policy.Name,
+			ViolationState: condition.Policy.ViolationState,
+			Type:           violation.Type,
+			Component:      component,
+			Condition:      fmt.Sprintf("%s %s %s", condition.Subject, condition.Operator, condition.Value),
+		})
+	}
+	return result
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+)
+
+const DefaultWalkWorkers = 8
+
+type WalkOptions struct {
+	// Workers is the number of concurrent component lookups, values below one use a single worker.
+	Workers int
+	// FailFast cancels the remaining lookups on the first component error.
+	FailFast bool
+}
+
+var DefaultWalkOptions = WalkOptions{Workers: DefaultWalkWorkers}
+
+// ComponentError is the failure of a single component lookup during an sbom walk.
+type ComponentError struct {
+	Index int
+	Name  string
+	Purl  string
+	Err   error
+}
+
+func (e *ComponentError) Error() string {
+	return fmt.Sprintf("Name: %s Purl: %s Err: %v", e.Name, e.Purl, e.Err)
+}
+
+func (e *ComponentError) Unwrap() error {
+	return e.Err
+}
+
+// WalkError aggregates the component errors of an sbom walk, ordered as the components appear in the sbom.
+type WalkError struct {
+	Errors []*ComponentError
+}
+
+func (e *WalkError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, component_err := range e.Errors {
+		msgs = append(msgs, component_err.Error())
+	}
+	return fmt.Sprintf("%d component lookups failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// RateLimiter spaces requests evenly, a single limiter may be shared by several clients.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter allows requests_per_second requests per second, a rate of zero or below does not limit.
+func NewRateLimiter(requests_per_second float64) *RateLimiter {
+	if requests_per_second <= 0 {
+		return &RateLimiter{}
+	}
+	return &RateLimiter{interval: time.Duration(float64(time.Second) / requests_per_second)}
+}
+
+// Wait blocks until the next request is allowed or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetRateLimiter limits every request sent by the client, nil removes the limit.
+func (depClient *DepTrackClient) SetRateLimiter(limiter *RateLimiter) {
+	depClient.limiter = limiter
+}
+
+// poolError is the failure of the pool task at index.
+type poolError struct {
+	index int
+	err   error
+}
+
+// runPool calls fn for the indexes below count using a bounded worker pool, fn must be safe for concurrent use.
+// Task errors are returned ordered by index, with FailFast the remaining tasks are skipped on the first error
+// and the cancellation errors of the tasks still running are dropped.
+func runPool(ctx context.Context, count int, opts WalkOptions, fn func(ctx context.Context, index int) error) ([]poolError, error) {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	pool_ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tasks := make(chan int)
+	var mu sync.Mutex
+	var pool_errors []poolError
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range tasks {
+				if err := fn(pool_ctx, index); err != nil {
+					mu.Lock()
+					if !opts.FailFast || pool_ctx.Err() == nil || !errors.Is(err, context.Canceled) {
+						pool_errors = append(pool_errors, poolError{index: index, err: err})
+					}
+					if opts.FailFast {
+						cancel()
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for index := 0; index < count; index++ {
+		select {
+		case tasks <- index:
+		case <-pool_ctx.Done():
+			break dispatch
+		}
+	}
+	close(tasks)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(pool_errors, func(i, j int) bool {
+		return pool_errors[i].index < pool_errors[j].index
+	})
+	return pool_errors, nil
+}
+
+// WalkSbomComponents calls fn for every library component of the sbom using a bounded worker pool.
+// Component errors are collected into a *WalkError, fn must be safe for concurrent use.
+func (depClient *DepTrackClient) WalkSbomComponents(ctx context.Context, bom *cdx.BOM, opts WalkOptions, fn func(ctx context.Context, index int, component cdx.Component) error) error {
+	if bom.Components == nil {
+		return nil
+	}
+
+	var indexes []int
+	for index, component := range *bom.Components {
+		if depClient.checkComponentType(component) {
+			indexes = append(indexes, index)
+		}
+	}
+
+	pool_errors, err := runPool(ctx, len(indexes), opts, func(ctx context.Context, i int) error {
+		component := (*bom.Components)[indexes[i]]
+		if err := fn(ctx, indexes[i], component); err != nil {
+			log.Debugf("Component lookup error, Purl: %s Err: %+v", component.PackageURL, err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(pool_errors) == 0 {
+		return nil
+	}
+	if opts.FailFast {
+		return pool_errors[0].err
+	}
+
+	walk_err := &WalkError{}
+	for _, pool_err := range pool_errors {
+		component := (*bom.Components)[indexes[pool_err.index]]
+		walk_err.Errors = append(walk_err.Errors, &ComponentError{Index: indexes[pool_err.index], Name: component.Name, Purl: component.PackageURL, Err: pool_err.err})
+	}
+	return walk_err
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	ApiProjectClone    = "/project/clone"
+	ApiProjectChildren = "children"
+)
+
+// Project classifiers as used by dependency track
+const (
+	ClassifierApplication     = "APPLICATION"
+	ClassifierFramework       = "FRAMEWORK"
+	ClassifierLibrary         = "LIBRARY"
+	ClassifierContainer       = "CONTAINER"
+	ClassifierOperatingSystem = "OPERATING_SYSTEM"
+	ClassifierDevice          = "DEVICE"
+	ClassifierFirmware        = "FIRMWARE"
+	ClassifierFile            = "FILE"
+)
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+type ExternalReference struct {
+	Type    string `json:"type,omitempty"`
+	Url     string `json:"url,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ProjectRef references another project, used for the project parent.
+type ProjectRef struct {
+	UUID    string `json:"uuid"`
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// ProjectPatch holds the project fields to patch, nil fields are left unchanged.
+type ProjectPatch struct {
+	Name               *string             `json:"name,omitempty"`
+	Version            *string             `json:"version,omitempty"`
+	Group              *string             `json:"group,omitempty"`
+	Publisher          *string             `json:"publisher,omitempty"`
+	Description        *string             `json:"description,omitempty"`
+	Classifier         *string             `json:"classifier,omitempty"`
+	Purl               *string             `json:"purl,omitempty"`
+	Cpe                *string             `json:"cpe,omitempty"`
+	SwidTagId          *string             `json:"swidTagId,omitempty"`
+	Active             *bool               `json:"active,omitempty"`
+	Tags               []Tag               `json:"tags,omitempty"`
+	ExternalReferences []ExternalReference `json:"externalReferences,omitempty"`
+	Parent             *ProjectRef         `json:"parent,omitempty"`
+}
+
+type CloneProjectParams struct {
+	Project             string `json:"project"`
+	Version             string `json:"version"`
+	IncludeTags         bool   `json:"includeTags"`
+	IncludeProperties   bool   `json:"includeProperties"`
+	IncludeComponents   bool   `json:"includeComponents"`
+	IncludeServices     bool   `json:"includeServices"`
+	IncludeAuditHistory bool   `json:"includeAuditHistory"`
+	IncludeACL          bool   `json:"includeACL"`
+}
+
+func projectApi(uuid string) string {
+	return ApiProject + "/" + uuid
+}
+
+func (depClient *DepTrackClient) GetProjectByUUIDContext(ctx context.Context, uuid string) (*Project, error) {
+	var project Project
+	if err := depClient.GetJsonContext(ctx, projectApi(uuid), &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (depClient *DepTrackClient) GetProjectByUUID(uuid string) (*Project, error) {
+	return depClient.GetProjectByUUIDContext(context.Background(), uuid)
+}
+
+// CreateProjectContext creates the project, a project with the same name and version is returned as a conflict.
+func (depClient *DepTrackClient) CreateProjectContext(ctx context.Context, project *Project) (*Project, error) {
+	var created Project
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiProject, project, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreateProject(project *Project) (*Project, error) {
+	return depClient.CreateProjectContext(context.Background(), project)
+}
+
+// UpdateProjectContext replaces the project identified by project.UUID.
+func (depClient *DepTrackClient) UpdateProjectContext(ctx context.Context, project *Project) (*Project, error) {
+	var updated Project
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiProject, project, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdateProject(project *Project) (*Project, error) {
+	return depClient.UpdateProjectContext(context.Background(), project)
+}
+
+func (depClient *DepTrackClient) PatchProjectContext(ctx context.Context, uuid string, patch *ProjectPatch) (*Project, error) {
+	var patched Project
+	if err := depClient.SendJsonContext(ctx, http.MethodPatch, projectApi(uuid), patch, &patched); err != nil {
+		return nil, err
+	}
+	return &patched, nil
+}
+
+func (depClient *DepTrackClient) PatchProject(uuid string, patch *ProjectPatch) (*Project, error) {
+	return depClient.PatchProjectContext(context.Background(), uuid, patch)
+}
+
+func (depClient *DepTrackClient) DeleteProjectContext(ctx context.Context, uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, projectApi(uuid), nil, nil)
+}
+
+func (depClient *DepTrackClient) DeleteProject(uuid string) error {
+	return depClient.DeleteProjectContext(context.Background(), uuid)
+}
+
+// CloneProjectContext clones params.Project into a new project version, the clone is created asynchronously by the server.
+func (depClient *DepTrackClient) CloneProjectContext(ctx context.Context, params CloneProjectParams) error {
+	return depClient.SendJsonContext(ctx, http.MethodPut, ApiProjectClone, params, nil)
+}
+
+func (depClient *DepTrackClient) CloneProject(params CloneProjectParams) error {
+	return depClient.CloneProjectContext(context.Background(), params)
+}
+
+func (depClient *DepTrackClient) GetProjectChildrenContext(ctx context.Context, uuid string) (ProjectList, error) {
+	if err := depClient.requireContext(ctx, CapabilityProjectHierarchy); err != nil {
+		return nil, err
+	}
+	var project_list ProjectList
+	if err := depClient.CollectAllContext(ctx, projectApi(uuid)+"/"+ApiProjectChildren, nil, DefaultPageSize, &project_list); err != nil {
+		return nil, err
+	}
+	return project_list, nil
+}
+
+func (depClient *DepTrackClient) GetProjectChildren(uuid string) (ProjectList, error) {
+	return depClient.GetProjectChildrenContext(context.Background(), uuid)
+}
+
+// SetProjectParentContext moves the project under the parent_uuid project.
+func (depClient *DepTrackClient) SetProjectParentContext(ctx context.Context, uuid string, parent_uuid string) (*Project, error) {
+	if err := depClient.requireContext(ctx, CapabilityProjectHierarchy); err != nil {
+		return nil, err
+	}
+	return depClient.PatchProjectContext(ctx, uuid, &ProjectPatch{Parent: &ProjectRef{UUID: parent_uuid}})
+}
+
+func (depClient *DepTrackClient) SetProjectParent(uuid string, parent_uuid string) (*Project, error) {
+	return depClient.SetProjectParentContext(context.Background(), uuid, parent_uuid)
+}
+
+// GetProjectTreeContext returns the project with its children resolved recursively.
+func (depClient *DepTrackClient) GetProjectTreeContext(ctx context.Context, uuid string) (*Project, error) {
+	project, err := depClient.GetProjectByUUIDContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := depClient.GetProjectChildrenContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	project.Children = nil
+	for _, child := range children {
+		child_tree, err := depClient.GetProjectTreeContext(ctx, child.UUID)
+		if err != nil {
+			return nil, err
+		}
+		project.Children = append(project.Children, *child_tree)
+	}
+	return project, nil
+}
+
+func (depClient *DepTrackClient) GetProjectTree(uuid string) (*Project, error) {
+	return depClient.GetProjectTreeContext(context.Background(), uuid)
+}
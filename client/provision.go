@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultAdminUsername = "admin"
+	DefaultAdminPassword = "admin"
+)
+
+type TeamConfig struct {
+	Name string `json:"name" yaml:"name"`
+	// Permissions is the exact permission set of the team, permissions not listed are removed.
+	Permissions []string `json:"permissions" yaml:"permissions"`
+	// ApiKey makes sure the team has at least one api key.
+	ApiKey bool `json:"apiKey" yaml:"apiKey"`
+}
+
+type ProvisionConfig struct {
+	AdminUsername string `json:"adminUsername" yaml:"adminUsername"`
+	// AdminInitialPassword is the password to change on a fresh server, defaults to admin.
+	AdminInitialPassword string       `json:"adminInitialPassword" yaml:"adminInitialPassword"`
+	AdminPassword        string       `json:"adminPassword" yaml:"adminPassword"`
+	Teams                []TeamConfig `json:"teams" yaml:"teams"`
+}
+
+type ProvisionResult struct {
+	Teams TeamList
+	// GeneratedKeys holds the api keys generated by this run by team name,
+	// newer servers only show a key in full when it is created.
+	GeneratedKeys map[string]ApiKey
+	Changes       []string
+}
+
+func (depClient *DepTrackClient) Provision(cfg ProvisionConfig) (*ProvisionResult, error) {
+	return depClient.ProvisionContext(context.Background(), cfg)
+}
+
+// ProvisionContext converges the server to cfg, running it again on a provisioned server changes nothing.
+// The client is logged in as the admin user.
+func (depClient *DepTrackClient) ProvisionContext(ctx context.Context, cfg ProvisionConfig) (*ProvisionResult, error) {
+	result := &ProvisionResult{}
+	if err := depClient.provisionAdmin(ctx, cfg, result); err != nil {
+		return nil, err
+	}
+
+	team_list, err := depClient.GetTeamsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, team_cfg := range cfg.Teams {
+		team, err := depClient.provisionTeam(ctx, team_list.FindByName(team_cfg.Name), team_cfg, result)
+		if err != nil {
+			return nil, err
+		}
+		result.Teams = append(result.Teams, *team)
+	}
+	return result, nil
+}
+
+func (depClient *DepTrackClient) provisionAdmin(ctx context.Context, cfg ProvisionConfig, result *ProvisionResult) error {
+	username := cfg.AdminUsername
+	if username == "" {
+		username = DefaultAdminUsername
+	}
+	initial_password := cfg.AdminInitialPassword
+	if initial_password == "" {
+		initial_password = DefaultAdminPassword
+	}
+
+	err := depClient.LoginContext(ctx, username, cfg.AdminPassword)
+	if !IsUnauthorized(err) {
+		return err
+	}
+
+	// A fresh server requires the initial password to be changed before login
+	if err := depClient.ForceChangePasswordContext(ctx, username, initial_password, cfg.AdminPassword); err != nil {
+		return err
+	}
+	result.addChange("changed %s password", username)
+	return depClient.LoginContext(ctx, username, cfg.AdminPassword)
+}
+
+type TeamChangeKind string
+
+const (
+	TeamCreate           TeamChangeKind = "create"
+	TeamAddPermission    TeamChangeKind = "add permission"
+	TeamRemovePermission TeamChangeKind = "remove permission"
+	TeamGenerateApiKey   TeamChangeKind = "generate api key"
+)
+
+// TeamChange is a single step converging a team to its TeamConfig.
+type TeamChange struct {
+	Kind       TeamChangeKind
+	Team       string
+	Permission string
+}
+
+func (change TeamChange) String() string {
+	if change.Permission != "" {
+		return string(change.Kind) + " " + change.Permission
+	}
+	return string(change.Kind)
+}
+
+// PlanTeam lists the changes converging team to team_cfg in the order they must be applied, a nil team is created.
+func PlanTeam(team *Team, team_cfg TeamConfig) []TeamChange {
+	var changes []TeamChange
+	if team == nil {
+		changes = append(changes, TeamChange{Kind: TeamCreate, Team: team_cfg.Name})
+		team = &Team{Name: team_cfg.Name}
+	}
+
+	for _, permission := range team_cfg.Permissions {
+		if !team.HasPermission(permission) {
+			changes = append(changes, TeamChange{Kind: TeamAddPermission, Team: team.Name, Permission: permission})
+		}
+	}
+	for _, permission := range team.Permissions {
+		if !containsString(team_cfg.Permissions, permission.Name) {
+			changes = append(changes, TeamChange{Kind: TeamRemovePermission, Team: team.Name, Permission: permission.Name})
+		}
+	}
+	if team_cfg.ApiKey && len(team.ApiKeys) == 0 {
+		changes = append(changes, TeamChange{Kind: TeamGenerateApiKey, Team: team.Name})
+	}
+	return changes
+}
+
+// ApplyTeamChangeContext applies change to team, nil for TeamCreate, and returns the updated team.
+// A generated api key is the last key of the returned team.
+func (depClient *DepTrackClient) ApplyTeamChangeContext(ctx context.Context, team *Team, change TeamChange) (*Team, error) {
+	switch change.Kind {
+	case TeamCreate:
+		return depClient.CreateTeamContext(ctx, &DepTrackTeamPut{Name: change.Team})
+	case TeamAddPermission:
+		return depClient.AddTeamPermissionContext(ctx, team.UUID, change.Permission)
+	case TeamRemovePermission:
+		return depClient.RemoveTeamPermissionContext(ctx, team.UUID, change.Permission)
+	case TeamGenerateApiKey:
+		api_key, err := depClient.GenerateApiKeyContext(ctx, team.UUID)
+		if err != nil {
+			return nil, err
+		}
+		updated := *team
+		updated.ApiKeys = append(append([]ApiKey{}, team.ApiKeys...), *api_key)
+		return &updated, nil
+	}
+	return nil, fmt.Errorf("unknown team change %s", change.Kind)
+}
+
+func (depClient *DepTrackClient) provisionTeam(ctx context.Context, team *Team, team_cfg TeamConfig, result *ProvisionResult) (*Team, error) {
+	for _, change := range PlanTeam(team, team_cfg) {
+		var err error
+		if team, err = depClient.ApplyTeamChangeContext(ctx, team, change); err != nil {
+			return nil, err
+		}
+		if change.Kind == TeamGenerateApiKey {
+			if result.GeneratedKeys == nil {
+				result.GeneratedKeys = make(map[string]ApiKey)
+			}
+			result.GeneratedKeys[team.Name] = team.ApiKeys[len(team.ApiKeys)-1]
+		}
+		result.addChange("team %s: %s", team.Name, change)
+	}
+	return team, nil
+}
+
+func (result *ProvisionResult) addChange(format string, args ...interface{}) {
+	change := fmt.Sprintf(format, args...)
+	log.Info("Provision: ", change)
+	result.Changes = append(result.Changes, change)
+}
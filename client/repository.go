@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+const ApiRepository = "/repository"
+
+type Repository struct {
+	UUID                   string `json:"uuid,omitempty"`
+	Type                   string `json:"type"`
+	Identifier             string `json:"identifier"`
+	Url                    string `json:"url,omitempty"`
+	ResolutionOrder        int    `json:"resolutionOrder"`
+	Enabled                bool   `json:"enabled"`
+	Internal               bool   `json:"internal"`
+	AuthenticationRequired bool   `json:"authenticationRequired"`
+	Username               string `json:"username,omitempty"`
+	Password               string `json:"password,omitempty"`
+}
+
+type RepositoryList []Repository
+
+func (depClient *DepTrackClient) GetRepositoriesContext(ctx context.Context) (RepositoryList, error) {
+	var repository_list RepositoryList
+	if err := depClient.CollectAllContext(ctx, ApiRepository, nil, DefaultPageSize, &repository_list); err != nil {
+		return nil, err
+	}
+	return repository_list, nil
+}
+
+func (depClient *DepTrackClient) GetRepositories() (RepositoryList, error) {
+	return depClient.GetRepositoriesContext(context.Background())
+}
+
+func (depClient *DepTrackClient) CreateRepositoryContext(ctx context.Context, repository *Repository) (*Repository, error) {
+	var created Repository
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, ApiRepository, repository, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreateRepository(repository *Repository) (*Repository, error) {
+	return depClient.CreateRepositoryContext(context.Background(), repository)
+}
+
+func (depClient *DepTrackClient) UpdateRepositoryContext(ctx context.Context, repository *Repository) (*Repository, error) {
+	var updated Repository
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, ApiRepository, repository, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdateRepository(repository *Repository) (*Repository, error) {
+	return depClient.UpdateRepositoryContext(context.Background(), repository)
+}
+
+func (depClient *DepTrackClient) DeleteRepositoryContext(ctx context.Context, uuid string) error {
+	return depClient.SendJsonContext(ctx, http.MethodDelete, ApiRepository+"/"+uuid, nil, nil)
+}
+
+func (depClient *DepTrackClient) DeleteRepository(uuid string) error {
+	return depClient.DeleteRepositoryContext(context.Background(), uuid)
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	ApiKeyHeader        = "X-Api-Key"
+	AuthorizationHeader = "Authorization"
+	ContentTypeHeader   = "Content-Type"
+	AcceptHeader        = "Accept"
+	JsonContentType     = "application/json"
+)
+
+// requestUrl joins the api server path with an api endpoint and encodes params as its query string.
+func (depClient *DepTrackClient) requestUrl(api string, params interface{}) (string, error) {
+	full_url := strings.TrimRight(depClient.apiServerPath, "/") + "/" + strings.TrimLeft(api, "/")
+	values, err := encodeParams(params)
+	if err != nil {
+		return "", err
+	}
+	if len(values) != 0 {
+		full_url += "?" + values.Encode()
+	}
+	return full_url, nil
+}
+
+// NewRequestContext creates an authenticated request bound to ctx for the given api endpoint.
+func (depClient *DepTrackClient) NewRequestContext(ctx context.Context, method string, api string, params interface{}, body io.Reader) (*http.Request, error) {
+	full_url, err := depClient.requestUrl(api, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, full_url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if depClient.accessToken != "" {
+		req.Header.Set(AuthorizationHeader, "Bearer "+depClient.accessToken)
+	} else if depClient.token != "" {
+		req.Header.Set(ApiKeyHeader, depClient.token)
+	}
+	req.Header.Set(AcceptHeader, JsonContentType)
+	return req, nil
+}
+
+// DoContext executes the request bound to ctx, the caller must close the response body.
+// Non 2xx responses are returned as an *APIError.
+func (depClient *DepTrackClient) DoContext(ctx context.Context, method string, api string, params interface{}, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := depClient.NewRequestContext(ctx, method, api, params, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set(ContentTypeHeader, contentType)
+	}
+
+	return depClient.doRequest(req)
+}
+
+// SetHTTPClient replaces the http client every request is sent with, e.g. to configure TLS, a proxy or timeouts.
+func (depClient *DepTrackClient) SetHTTPClient(http_client *http.Client) {
+	depClient.httpClient = http_client
+}
+
+func (depClient *DepTrackClient) doRequest(req *http.Request) (*http.Response, error) {
+	if depClient.limiter != nil {
+		if err := depClient.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := depClient.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp, req.Method, req.URL.Path)
+	}
+	return resp, nil
+}
+
+func (depClient *DepTrackClient) PostContext(ctx context.Context, api string, contentType string, body io.Reader) (*http.Response, error) {
+	return depClient.DoContext(ctx, http.MethodPost, api, nil, contentType, body)
+}
+
+func (depClient *DepTrackClient) GetJsonContext(ctx context.Context, api string, dst interface{}) error {
+	return depClient.GetJsonWithParamsContext(ctx, api, nil, dst)
+}
+
+func (depClient *DepTrackClient) GetJsonWithParamsContext(ctx context.Context, api string, params interface{}, dst interface{}) error {
+	resp, err := depClient.DoContext(ctx, http.MethodGet, api, params, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
+
+// GetRawContext reads the response body of api as is, accept selects the response media type.
+func (depClient *DepTrackClient) GetRawContext(ctx context.Context, api string, params interface{}, accept string) ([]byte, error) {
+	req, err := depClient.NewRequestContext(ctx, http.MethodGet, api, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(AcceptHeader, accept)
+
+	resp, err := depClient.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// SendJsonContext sends body json encoded and decodes the response into dst, a nil dst discards the response.
+func (depClient *DepTrackClient) SendJsonContext(ctx context.Context, method string, api string, body interface{}, dst interface{}) error {
+	var reader io.Reader
+	content_type := ""
+	if body != nil {
+		v, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(v)
+		content_type = JsonContentType
+	}
+
+	resp, err := depClient.DoContext(ctx, method, api, nil, content_type, reader)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if dst == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
+
+// encodeParams converts a json tagged params struct (or url.Values) into query values.
+func encodeParams(params interface{}) (url.Values, error) {
+	values := url.Values{}
+	switch p := params.(type) {
+	case nil:
+		return values, nil
+	case url.Values:
+		return p, nil
+	}
+
+	v, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(v, &fields); err != nil {
+		return nil, err
+	}
+
+	for key, field := range fields {
+		switch f := field.(type) {
+		case nil:
+			continue
+		case string:
+			values.Set(key, f)
+		case float64:
+			values.Set(key, strconv.FormatFloat(f, 'f', -1, 64))
+		case map[string]interface{}:
+			// Nested param structs are flattened into the same query
+			nested, err := encodeParams(f)
+			if err != nil {
+				return nil, err
+			}
+			for nested_key := range nested {
+				values[nested_key] = nested[nested_key]
+			}
+		default:
+			values.Set(key, fmt.Sprint(f))
+		}
+	}
+	return values, nil
+}
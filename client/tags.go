@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	ApiProjectTag        = "/project/tag"
+	ApiProjectClassifier = "/project/classifier"
+	ApiProjectProperty   = "property"
+)
+
+// Property types as used by dependency track
+const (
+	PropertyTypeString          = "STRING"
+	PropertyTypeInteger         = "INTEGER"
+	PropertyTypeNumber          = "NUMBER"
+	PropertyTypeBoolean         = "BOOLEAN"
+	PropertyTypeEncryptedString = "ENCRYPTEDSTRING"
+	PropertyTypeTimestamp       = "TIMESTAMP"
+	PropertyTypeUrl             = "URL"
+	PropertyTypeUUID            = "UUID"
+)
+
+type ProjectProperty struct {
+	GroupName     string `json:"groupName"`
+	PropertyName  string `json:"propertyName"`
+	PropertyValue string `json:"propertyValue,omitempty"`
+	PropertyType  string `json:"propertyType"`
+	Description   string `json:"description,omitempty"`
+}
+
+type ProjectPropertyList []ProjectProperty
+
+func NewTags(names ...string) []Tag {
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name})
+	}
+	return tags
+}
+
+// TagsParam joins the tags into the comma separated form used by the bom upload projectTags field.
+func TagsParam(tags []Tag) string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func projectPropertyApi(uuid string) string {
+	return projectApi(uuid) + "/" + ApiProjectProperty
+}
+
+func (depClient *DepTrackClient) GetProjectPropertiesContext(ctx context.Context, uuid string) (ProjectPropertyList, error) {
+	var property_list ProjectPropertyList
+	if err := depClient.GetJsonContext(ctx, projectPropertyApi(uuid), &property_list); err != nil {
+		return nil, err
+	}
+	return property_list, nil
+}
+
+func (depClient *DepTrackClient) GetProjectProperties(uuid string) (ProjectPropertyList, error) {
+	return depClient.GetProjectPropertiesContext(context.Background(), uuid)
+}
+
+func (depClient *DepTrackClient) CreateProjectPropertyContext(ctx context.Context, uuid string, property *ProjectProperty) (*ProjectProperty, error) {
+	var created ProjectProperty
+	if err := depClient.SendJsonContext(ctx, http.MethodPut, projectPropertyApi(uuid), property, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (depClient *DepTrackClient) CreateProjectProperty(uuid string, property *ProjectProperty) (*ProjectProperty, error) {
+	return depClient.CreateProjectPropertyContext(context.Background(), uuid, property)
+}
+
+// UpdateProjectPropertyContext updates the value of the property matching the group and property name.
+func (depClient *DepTrackClient) UpdateProjectPropertyContext(ctx context.Context, uuid string, property *ProjectProperty) (*ProjectProperty, error) {
+	var updated ProjectProperty
+	if err := depClient.SendJsonContext(ctx, http.MethodPost, projectPropertyApi(uuid), property, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (depClient *DepTrackClient) UpdateProjectProperty(uuid string, property *ProjectProperty) (*ProjectProperty, error) {
+	return depClient.UpdateProjectPropertyContext(context.Background(), uuid, property)
+}
+
+// SetProjectPropertyContext creates the property or updates it if it already exists.
+func (depClient *DepTrackClient) SetProjectPropertyContext(ctx context.Context, uuid string, property *ProjectProperty) (*ProjectProperty, error) {
+	created, err := depClient.CreateProjectPropertyContext(ctx, uuid, property)
+	if IsConflict(err) {
+		return depClient.UpdateProjectPropertyContext(ctx, uuid, property)
+	}
+	return created, err
+}
+
+func (depClient *DepTrackClient) SetProjectProperty(uuid string, property *ProjectProperty) (*ProjectProperty, error) {
+	return depClient.SetProjectPropertyContext(context.Background(), uuid, property)
+}
+
+func (depClient *DepTrackClient) DeleteProjectPropertyContext(ctx context.Context, uuid string, group_name string, property_name string) error {
+	property := ProjectProperty{GroupName: group_name, PropertyName: property_name}
+	return depClient.SendJsonContext(ctx, http.MethodDelete, projectPropertyApi(uuid), property, nil)
+}
+
+func (depClient *DepTrackClient) DeleteProjectProperty(uuid string, group_name string, property_name string) error {
+	return depClient.DeleteProjectPropertyContext(context.Background(), uuid, group_name, property_name)
+}
+
+// AddProjectTagsContext adds the tags missing from the project, existing tags are kept.
+func (depClient *DepTrackClient) AddProjectTagsContext(ctx context.Context, uuid string, tags ...Tag) (*Project, error) {
+	project, err := depClient.GetProjectByUUIDContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := project.Tags
+	for _, tag := range tags {
+		if !HasTag(merged, tag.Name) {
+			merged = append(merged, tag)
+		}
+	}
+	return depClient.PatchProjectContext(ctx, uuid, &ProjectPatch{Tags: merged})
+}
+
+func (depClient *DepTrackClient) AddProjectTags(uuid string, tags ...Tag) (*Project, error) {
+	return depClient.AddProjectTagsContext(context.Background(), uuid, tags...)
+}
+
+func HasTag(tags []Tag, name string) bool {
+	for _, tag := range tags {
+		if strings.EqualFold(tag.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (depClient *DepTrackClient) GetProjectsByTagContext(ctx context.Context, tag string) (ProjectList, error) {
+	var project_list ProjectList
+	if err := depClient.CollectAllContext(ctx, ApiProjectTag+"/"+url.PathEscape(tag), nil, DefaultPageSize, &project_list); err != nil {
+		return nil, err
+	}
+	return project_list, nil
+}
+
+func (depClient *DepTrackClient) GetProjectsByTag(tag string) (ProjectList, error) {
+	return depClient.GetProjectsByTagContext(context.Background(), tag)
+}
+
+func (depClient *DepTrackClient) GetProjectsByClassifierContext(ctx context.Context, classifier string) (ProjectList, error) {
+	var project_list ProjectList
+	if err := depClient.CollectAllContext(ctx, ApiProjectClassifier+"/"+url.PathEscape(classifier), nil, DefaultPageSize, &project_list); err != nil {
+		return nil, err
+	}
+	return project_list, nil
+}
+
+func (depClient *DepTrackClient) GetProjectsByClassifier(classifier string) (ProjectList, error) {
+	return depClient.GetProjectsByClassifierContext(context.Background(), classifier)
+}
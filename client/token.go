@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ApiEventTokenQuery = "/event/token"
+	// EventTokenVersion is the first server version serving /event/token, deprecating /bom/token.
+	EventTokenVersion = "4.11.0"
+)
+
+type BomProcessingState int
+
+const (
+	// BomStateUnknown is reported for tokens the server does not know, when the server tells them apart.
+	BomStateUnknown BomProcessingState = iota
+	BomStateProcessing
+	BomStateFinished
+)
+
+func (state BomProcessingState) String() string {
+	switch state {
+	case BomStateProcessing:
+		return "processing"
+	case BomStateFinished:
+		return "finished"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrUnknownToken is returned while waiting on a token the server does not know.
+var ErrUnknownToken = errors.New("unknown processing token")
+
+// tokenApi picks the token endpoint by server version, falling back to /bom/token when it can not be detected.
+func (depClient *DepTrackClient) tokenApi(ctx context.Context) string {
+	info, err := depClient.ServerInfoContext(ctx)
+	if err != nil {
+		log.Debugf("Server version detection failed, using %s, Err: %+v", ApiSbomTokenQuery, err)
+		return ApiSbomTokenQuery
+	}
+	if info.AtLeast(Capabilities[CapabilityEventToken]) {
+		return ApiEventTokenQuery
+	}
+	return ApiSbomTokenQuery
+}
+
+// GetBomProcessingStateContext returns the processing state of an upload token.
+// Servers answering a 404 or without the processing field report BomStateUnknown,
+// other servers report unknown tokens as finished.
+func (depClient *DepTrackClient) GetBomProcessingStateContext(ctx context.Context, sbom_uuid string) (BomProcessingState, error) {
+	var sbomProcessingState SbomProcessingState
+	sbom_token_query := depClient.tokenApi(ctx) + "/" + sbom_uuid
+	err := depClient.GetJsonContext(ctx, sbom_token_query, &sbomProcessingState)
+	if IsNotFound(err) {
+		return BomStateUnknown, nil
+	}
+	if err != nil {
+		return BomStateUnknown, err
+	}
+
+	switch {
+	case sbomProcessingState.Processing == nil:
+		return BomStateUnknown, nil
+	case *sbomProcessingState.Processing:
+		return BomStateProcessing, nil
+	default:
+		return BomStateFinished, nil
+	}
+}
+
+func (depClient *DepTrackClient) GetBomProcessingState(sbom_uuid string) (BomProcessingState, error) {
+	return depClient.GetBomProcessingStateContext(context.Background(), sbom_uuid)
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ApiVersionPath = "version"
+	// ServerInfoFailureTTL is how long a failed /version call is reused before the server is asked again.
+	ServerInfoFailureTTL = 5 * time.Minute
+)
+
+type FrameworkInfo struct {
+	Name      string `json:"name,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+	UUID      string `json:"uuid,omitempty"`
+}
+
+type ServerInfo struct {
+	Application string        `json:"application,omitempty"`
+	Version     string        `json:"version,omitempty"`
+	Timestamp   string        `json:"timestamp,omitempty"`
+	UUID        string        `json:"uuid,omitempty"`
+	Framework   FrameworkInfo `json:"framework"`
+}
+
+// serverInfoCache holds the server info of the first successful /version call, or the last failure.
+type serverInfoCache struct {
+	mu        sync.Mutex
+	info      *ServerInfo
+	err       error
+	failed_at time.Time
+}
+
+// versionUrl is served next to the versioned api, http://host/api/v1 serves it at http://host/api/version.
+func (depClient *DepTrackClient) versionUrl() string {
+	base := strings.TrimSuffix(strings.TrimRight(depClient.apiServerPath, "/"), "/v1")
+	return base + "/" + ApiVersionPath
+}
+
+// ServerInfoContext returns the server version, the result is cached for the lifetime of the client
+// and a failure for ServerInfoFailureTTL, so servers without /version are not asked on every call.
+func (depClient *DepTrackClient) ServerInfoContext(ctx context.Context) (*ServerInfo, error) {
+	cache := &depClient.serverInfo
+	cache.mu.Lock()
+	info, err, failed_at := cache.info, cache.err, cache.failed_at
+	cache.mu.Unlock()
+	if info != nil {
+		return info, nil
+	}
+	if err != nil && time.Since(failed_at) < ServerInfoFailureTTL {
+		return nil, err
+	}
+
+	info, err = depClient.getServerInfoContext(ctx)
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up, the server did not fail
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if err != nil {
+		cache.err = err
+		cache.failed_at = time.Now()
+		return nil, err
+	}
+	cache.info = info
+	cache.err = nil
+	return info, nil
+}
+
+func (depClient *DepTrackClient) getServerInfoContext(ctx context.Context) (*ServerInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, depClient.versionUrl(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(AcceptHeader, JsonContentType)
+
+	resp, err := depClient.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var info ServerInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (depClient *DepTrackClient) ServerInfo() (*ServerInfo, error) {
+	return depClient.ServerInfoContext(context.Background())
+}
+
+// AtLeast reports whether the server version is at least version, e.g. "4.11.0".
+// Pre-release suffixes are ignored, an unparsable server version is never at least any version.
+func (info *ServerInfo) AtLeast(version string) bool {
+	current, ok := parseVersion(info.Version)
+	if !ok {
+		return false
+	}
+	wanted, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+
+	for i := range wanted {
+		if current[i] != wanted[i] {
+			return current[i] > wanted[i]
+		}
+	}
+	return true
+}
+
+// parseVersion parses the major, minor and patch numbers of a version like 4.11.3-SNAPSHOT.
+func parseVersion(version string) ([3]int, bool) {
+	var parsed [3]int
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return parsed, false
+	}
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return parsed, false
+		}
+		parsed[i] = number
+	}
+	return parsed, true
+}
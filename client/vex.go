@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+const (
+	ApiVex            = "/vex"
+	ApiVexProject     = "/vex/cyclonedx/project"
+	VexField          = "vex"
+	VexSpecVersion    = "1.4"
+	CycloneDxJsonType = "application/vnd.cyclonedx+json"
+)
+
+type VexSource struct {
+	Name string `json:"name,omitempty"`
+	Url  string `json:"url,omitempty"`
+}
+
+type VexReference struct {
+	Id     string     `json:"id,omitempty"`
+	Source *VexSource `json:"source,omitempty"`
+}
+
+type VexRating struct {
+	Source   *VexSource `json:"source,omitempty"`
+	Score    float64    `json:"score,omitempty"`
+	Severity string     `json:"severity,omitempty"`
+	Method   string     `json:"method,omitempty"`
+	Vector   string     `json:"vector,omitempty"`
+}
+
+type VexAdvisory struct {
+	Title string `json:"title,omitempty"`
+	Url   string `json:"url,omitempty"`
+}
+
+type VexAnalysis struct {
+	State         string   `json:"state,omitempty"`
+	Justification string   `json:"justification,omitempty"`
+	Response      []string `json:"response,omitempty"`
+	Detail        string   `json:"detail,omitempty"`
+}
+
+type VexAffectedVersion struct {
+	Version string `json:"version,omitempty"`
+	Range   string `json:"range,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+type VexAffects struct {
+	Ref      string               `json:"ref"`
+	Versions []VexAffectedVersion `json:"versions,omitempty"`
+}
+
+// VexVulnerability is a CycloneDX 1.4 vulnerability entry.
+type VexVulnerability struct {
+	BOMRef         string         `json:"bom-ref,omitempty"`
+	Id             string         `json:"id,omitempty"`
+	Source         *VexSource     `json:"source,omitempty"`
+	References     []VexReference `json:"references,omitempty"`
+	Ratings        []VexRating    `json:"ratings,omitempty"`
+	Cwes           []int          `json:"cwes,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	Detail         string         `json:"detail,omitempty"`
+	Recommendation string         `json:"recommendation,omitempty"`
+	Advisories     []VexAdvisory  `json:"advisories,omitempty"`
+	Created        string         `json:"created,omitempty"`
+	Published      string         `json:"published,omitempty"`
+	Updated        string         `json:"updated,omitempty"`
+	Analysis       *VexAnalysis   `json:"analysis,omitempty"`
+	Affects        []VexAffects   `json:"affects,omitempty"`
+}
+
+// VEX is a CycloneDX BOM with the vulnerabilities section, which cdx.BOM does not model.
+// Only the JSON format is supported.
+type VEX struct {
+	cdx.BOM
+	Vulnerabilities []VexVulnerability `json:"vulnerabilities,omitempty"`
+}
+
+type DepTrackVexPost struct {
+	Project        string `json:"project,omitempty"`
+	ProjectName    string `json:"projectName,omitempty"`
+	ProjectVersion string `json:"projectVersion,omitempty"`
+}
+
+func (depClient *DepTrackClient) ExportVEXContext(ctx context.Context, project_uuid string) (*VEX, error) {
+	if err := depClient.requireContext(ctx, CapabilityVex); err != nil {
+		return nil, err
+	}
+	raw, err := depClient.GetRawContext(ctx, ApiVexProject+"/"+project_uuid, nil, CycloneDxJsonType)
+	if err != nil {
+		return nil, err
+	}
+
+	var vex VEX
+	if err := json.Unmarshal(raw, &vex); err != nil {
+		return nil, err
+	}
+	return &vex, nil
+}
+
+func (depClient *DepTrackClient) ExportVEX(project_uuid string) (*VEX, error) {
+	return depClient.ExportVEXContext(context.Background(), project_uuid)
+}
+
+// ImportVEXContext uploads the vex analysis of project_uuid, the response token can be waited on like an sbom upload.
+func (depClient *DepTrackClient) ImportVEXContext(ctx context.Context, project_uuid string, vex *VEX, response *DepTrackSbomPostResponse) error {
+	if err := depClient.requireContext(ctx, CapabilityVex); err != nil {
+		return err
+	}
+	params := DepTrackVexPost{Project: project_uuid}
+	return depClient.postMultipartContext(ctx, VexField, ApiVex, project_uuid, params, func(part io.Writer) error {
+		encoder := json.NewEncoder(part)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(vex)
+	}, response)
+}
+
+func (depClient *DepTrackClient) ImportVEX(project_uuid string, vex *VEX, response *DepTrackSbomPostResponse) error {
+	return depClient.ImportVEXContext(context.Background(), project_uuid, vex, response)
+}
+
+// MergeVEX attaches the vex vulnerabilities to the components of bom.
+// Affected components are matched by purl, as bom refs differ between the vex and the bom,
+// vulnerabilities not affecting any bom component are dropped.
+func MergeVEX(bom *cdx.BOM, vex *VEX) *VEX {
+	merged := &VEX{BOM: *bom}
+	merged.SpecVersion = VexSpecVersion
+
+	vex_purls := make(map[string]string)
+	if vex.Components != nil {
+		for _, component := range *vex.Components {
+			vex_purls[component.BOMRef] = component.PackageURL
+		}
+	}
+
+	bom_refs := make(map[string]string)
+	if bom.Components != nil {
+		for _, component := range *bom.Components {
+			if component.PackageURL == "" {
+				continue
+			}
+			bom_refs[component.PackageURL] = component.BOMRef
+			if component.BOMRef == "" {
+				bom_refs[component.PackageURL] = component.PackageURL
+			}
+		}
+	}
+
+	for _, vulnerability := range vex.Vulnerabilities {
+		var affects []VexAffects
+		for _, affected := range vulnerability.Affects {
+			purl, ok := vex_purls[affected.Ref]
+			if !ok {
+				// Ref may already be a purl
+				purl = affected.Ref
+			}
+			if bom_ref, ok := bom_refs[purl]; ok {
+				affected.Ref = bom_ref
+				affects = append(affects, affected)
+			}
+		}
+		if len(affects) == 0 {
+			continue
+		}
+		vulnerability.Affects = affects
+		merged.Vulnerabilities = append(merged.Vulnerabilities, vulnerability)
+	}
+	return merged
+}
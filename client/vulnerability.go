@@ -0,0 +1,286 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+const (
+	ApiVulnerability       = "/vulnerability"
+	ApiVulnerabilitySource = "/vulnerability/source"
+)
+
+type Cwe struct {
+	CweId int    `json:"cweId,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// VulnerabilityAlias holds the ids of the same vulnerability in other sources, only the known ones are set.
+type VulnerabilityAlias struct {
+	CveId      string `json:"cveId,omitempty"`
+	GhsaId     string `json:"ghsaId,omitempty"`
+	OsvId      string `json:"osvId,omitempty"`
+	SonatypeId string `json:"sonatypeId,omitempty"`
+	SnykId     string `json:"snykId,omitempty"`
+	GsdId      string `json:"gsdId,omitempty"`
+	VulnDbId   string `json:"vulnDbId,omitempty"`
+	InternalId string `json:"internalId,omitempty"`
+}
+
+// Ids returns the set alias ids.
+func (alias VulnerabilityAlias) Ids() []string {
+	var ids []string
+	for _, id := range []string{alias.CveId, alias.GhsaId, alias.OsvId, alias.SonatypeId, alias.SnykId, alias.GsdId, alias.VulnDbId, alias.InternalId} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// VulnerableSoftware is an affected version range of a package, matched by purl or cpe.
+type VulnerableSoftware struct {
+	UUID                  string `json:"uuid,omitempty"`
+	Purl                  string `json:"purl,omitempty"`
+	PurlType              string `json:"purlType,omitempty"`
+	PurlNamespace         string `json:"purlNamespace,omitempty"`
+	PurlName              string `json:"purlName,omitempty"`
+	Cpe22                 string `json:"cpe22,omitempty"`
+	Cpe23                 string `json:"cpe23,omitempty"`
+	Vendor                string `json:"vendor,omitempty"`
+	Product               string `json:"product,omitempty"`
+	Version               string `json:"version,omitempty"`
+	VersionEndExcluding   string `json:"versionEndExcluding,omitempty"`
+	VersionEndIncluding   string `json:"versionEndIncluding,omitempty"`
+	VersionStartExcluding string `json:"versionStartExcluding,omitempty"`
+	VersionStartIncluding string `json:"versionStartIncluding,omitempty"`
+	Vulnerable            bool   `json:"vulnerable"`
+}
+
+// Affected component identity and version types of internal vulnerabilities
+const (
+	IdentityTypePurl = "PURL"
+	IdentityTypeCpe  = "CPE"
+	VersionTypeExact = "EXACT"
+	VersionTypeRange = "RANGE"
+)
+
+// AffectedComponent is an affected version, or version range, of internal vulnerabilities, matched by purl or cpe.
+type AffectedComponent struct {
+	UUID                  string `json:"uuid,omitempty"`
+	IdentityType          string `json:"identityType"`
+	Identity              string `json:"identity"`
+	VersionType           string `json:"versionType"`
+	Version               string `json:"version,omitempty"`
+	VersionEndExcluding   string `json:"versionEndExcluding,omitempty"`
+	VersionEndIncluding   string `json:"versionEndIncluding,omitempty"`
+	VersionStartExcluding string `json:"versionStartExcluding,omitempty"`
+	VersionStartIncluding string `json:"versionStartIncluding,omitempty"`
+}
+
+type Vulnraibility struct {
+	UUID           string `json:"uuid,omitempty"`
+	VulnId         string `json:"vulnId,omitempty"`
+	Source         string `json:"source,omitempty"`
+	Title          string `json:"title,omitempty"`
+	SubTitle       string `json:"subTitle,omitempty"`
+	Description    string `json:"description,omitempty"`
+	Detail         string `json:"detail,omitempty"`
+	Recommendation string `json:"recommendation,omitempty"`
+	// References is markdown, see ReferenceUrls
+	References string `json:"references,omitempty"`
+	Credits    string `json:"credits,omitempty"`
+	Severity   string `json:"severity,omitempty"`
+
+	// Created, Published and Updated are unix milliseconds
+	Created   int64 `json:"created,omitempty"`
+	Published int64 `json:"published,omitempty"`
+	Updated   int64 `json:"updated,omitempty"`
+
+	// Cwe is only sent by servers before 4.5, newer ones send Cwes
+	Cwe  *Cwe  `json:"cwe,omitempty"`
+	Cwes []Cwe `json:"cwes,omitempty"`
+
+	CvssV2BaseScore              float64 `json:"cvssV2BaseScore,omitempty"`
+	CvssV2ImpactSubScore         float64 `json:"cvssV2ImpactSubScore,omitempty"`
+	CvssV2ExploitabilitySubScore float64 `json:"cvssV2ExploitabilitySubScore,omitempty"`
+	CvssV2Vector                 string  `json:"cvssV2Vector,omitempty"`
+	CvssV3BaseScore              float64 `json:"cvssV3BaseScore,omitempty"`
+	CvssV3ImpactSubScore         float64 `json:"cvssV3ImpactSubScore,omitempty"`
+	CvssV3ExploitabilitySubScore float64 `json:"cvssV3ExploitabilitySubScore,omitempty"`
+	CvssV3Vector                 string  `json:"cvssV3Vector,omitempty"`
+	OwaspRRLikelihoodScore       float64 `json:"owaspRRLikelihoodScore,omitempty"`
+	OwaspRRTechnicalImpactScore  float64 `json:"owaspRRTechnicalImpactScore,omitempty"`
+	OwaspRRBusinessImpactScore   float64 `json:"owaspRRBusinessImpactScore,omitempty"`
+	OwaspRRVector                string  `json:"owaspRRVector,omitempty"`
+
+	EpssScore      float64 `json:"epssScore,omitempty"`
+	EpssPercentile float64 `json:"epssPercentile,omitempty"`
+
+	Aliases            []VulnerabilityAlias `json:"aliases,omitempty"`
+	VulnerableVersions string               `json:"vulnerableVersions,omitempty"`
+	PatchedVersions    string               `json:"patchedVersions,omitempty"`
+	VulnerableSoftware []VulnerableSoftware `json:"vulnerableSoftware,omitempty"`
+	AffectedComponents []AffectedComponent  `json:"affectedComponents,omitempty"`
+}
+
+func millisTime(millis int64) time.Time {
+	if millis == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, millis*int64(time.Millisecond))
+}
+
+// CreatedTime, PublishedTime and UpdatedTime are zero when the source does not report them.
+func (v *Vulnraibility) CreatedTime() time.Time {
+	return millisTime(v.Created)
+}
+
+func (v *Vulnraibility) PublishedTime() time.Time {
+	return millisTime(v.Published)
+}
+
+func (v *Vulnraibility) UpdatedTime() time.Time {
+	return millisTime(v.Updated)
+}
+
+// AllCwes returns Cwes, or the single Cwe sent by older servers.
+func (v *Vulnraibility) AllCwes() []Cwe {
+	if len(v.Cwes) == 0 && v.Cwe != nil {
+		return []Cwe{*v.Cwe}
+	}
+	return v.Cwes
+}
+
+// AliasIds returns the ids of all aliases, without the vulnerability own id.
+func (v *Vulnraibility) AliasIds() []string {
+	seen := map[string]bool{v.VulnId: true}
+	var ids []string
+	for _, alias := range v.Aliases {
+		for _, id := range alias.Ids() {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+var referenceUrlRegexp = regexp.MustCompile(`https?://[^\s\)\]<>"]+`)
+
+// ReferenceUrls extracts the urls of the markdown references, without duplicates.
+func (v *Vulnraibility) ReferenceUrls() []string {
+	seen := make(map[string]bool)
+	var urls []string
+	for _, url := range referenceUrlRegexp.FindAllString(v.References, -1) {
+		if !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// AffectedProject is a project with components affected by a vulnerability.
+type AffectedProject struct {
+	UUID                     string   `json:"uuid,omitempty"`
+	Name                     string   `json:"name,omitempty"`
+	Version                  string   `json:"version,omitempty"`
+	Active                   bool     `json:"active"`
+	DependencyGraphAvailable bool     `json:"dependencyGraphAvailable"`
+	AffectedComponentUuids   []string `json:"affectedComponentUuids,omitempty"`
+}
+
+type AffectedProjectList []AffectedProject
+
+func vulnerabilityApi(source string, vuln_id string) string {
+	return ApiVulnerabilitySource + "/" + url.PathEscape(source) + "/vuln/" + url.PathEscape(vuln_id)
+}
+
+// GetVulnerabilityContext looks up a vulnerability by its source, e.g. SourceNVD, and id.
+func (depClient *DepTrackClient) GetVulnerabilityContext(ctx context.Context, source string, vuln_id string) (*Vulnraibility, error) {
+	var vulnraibility Vulnraibility
+	if err := depClient.GetJsonContext(ctx, vulnerabilityApi(source, vuln_id), &vulnraibility); err != nil {
+		return nil, err
+	}
+	return &vulnraibility, nil
+}
+
+func (depClient *DepTrackClient) GetVulnerability(source string, vuln_id string) (*Vulnraibility, error) {
+	return depClient.GetVulnerabilityContext(context.Background(), source, vuln_id)
+}
+
+func (depClient *DepTrackClient) GetAffectedProjectsContext(ctx context.Context, source string, vuln_id string) (AffectedProjectList, error) {
+	var project_list AffectedProjectList
+	if err := depClient.CollectAllContext(ctx, vulnerabilityApi(source, vuln_id)+"/projects", nil, DefaultPageSize, &project_list); err != nil {
+		return nil, err
+	}
+	return project_list, nil
+}
+
+func (depClient *DepTrackClient) GetAffectedProjects(source string, vuln_id string) (AffectedProjectList, error) {
+	return depClient.GetAffectedProjectsContext(context.Background(), source, vuln_id)
+}
+
+// affectedFindingsContext collects the findings of the vulnerability in every project concurrently,
+// the findings of projects[i] are stored at index i.
+func (depClient *DepTrackClient) affectedFindingsContext(ctx context.Context, source string, vuln_id string, projects AffectedProjectList, opts WalkOptions) ([]FindingList, error) {
+	findings := make([]FindingList, len(projects))
+	pool_errors, err := runPool(ctx, len(projects), opts, func(ctx context.Context, index int) error {
+		finding_list, err := depClient.GetFindingsContext(ctx, projects[index].UUID, &FindingOptions{Suppressed: true, Source: source})
+		if err != nil {
+			return err
+		}
+		for _, finding := range finding_list {
+			if finding.Vulnerability.VulnId == vuln_id && finding.Vulnerability.Source == source {
+				findings[index] = append(findings[index], finding)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pool_errors) == 0 {
+		return findings, nil
+	}
+	if opts.FailFast {
+		return nil, pool_errors[0].err
+	}
+
+	walk_err := &WalkError{}
+	for _, pool_err := range pool_errors {
+		project := projects[pool_err.index]
+		walk_err.Errors = append(walk_err.Errors, &ComponentError{Index: pool_err.index, Name: project.Name + "@" + project.Version, Err: pool_err.err})
+	}
+	return findings, walk_err
+}
+
+// GetAffectedComponentsContext returns the findings of the vulnerability across all affected projects,
+// each holding the affected component, its project uuid and the analysis state.
+func (depClient *DepTrackClient) GetAffectedComponentsContext(ctx context.Context, source string, vuln_id string) (FindingList, error) {
+	projects, err := depClient.GetAffectedProjectsContext(ctx, source, vuln_id)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := DefaultWalkOptions
+	opts.FailFast = true
+	findings, err := depClient.affectedFindingsContext(ctx, source, vuln_id, projects, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var finding_list FindingList
+	for _, project_findings := range findings {
+		finding_list = append(finding_list, project_findings...)
+	}
+	return finding_list, nil
+}
+
+func (depClient *DepTrackClient) GetAffectedComponents(source string, vuln_id string) (FindingList, error) {
+	return depClient.GetAffectedComponentsContext(context.Background(), source, vuln_id)
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	retry "github.com/avast/retry-go"
+)
+
+// SbomPollResult reports a single poll of the sbom processing state.
+type SbomPollResult struct {
+	Token      string
+	Attempt    uint
+	Processing bool
+	State      BomProcessingState
+	Elapsed    time.Duration
+	Err        error
+}
+
+type WaitOptions struct {
+	// Timeout bounds the total wait, zero waits until the context is done.
+	Timeout time.Duration
+	// InitialInterval is the delay after the first poll, doubled on every following poll.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between two polls.
+	MaxInterval time.Duration
+	// Jitter is the maximum random delay added to each interval, zero disables it.
+	Jitter time.Duration
+	// OnPoll is called with every poll result.
+	OnPoll func(SbomPollResult)
+	// Progress receives every poll result, the send blocks until received or the wait is done.
+	Progress chan<- SbomPollResult
+}
+
+var DefaultWaitOptions = WaitOptions{
+	Timeout:         30 * time.Minute,
+	InitialInterval: 500 * time.Millisecond,
+	MaxInterval:     30 * time.Second,
+	Jitter:          250 * time.Millisecond,
+}
+
+var (
+	// ErrWaitTimeout matches any WaitTimeoutError using errors.Is.
+	ErrWaitTimeout    = errors.New("timed out waiting for sbom processing")
+	errSbomProcessing = errors.New("Processing")
+)
+
+// WaitTimeoutError is returned when the server is still processing the sbom once the wait timeout expires.
+type WaitTimeoutError struct {
+	Token    string
+	Attempts uint
+	Elapsed  time.Duration
+}
+
+func (e *WaitTimeoutError) Error() string {
+	return fmt.Sprintf("%s, Token: %s Attempts: %d Elapsed: %s", ErrWaitTimeout, e.Token, e.Attempts, e.Elapsed)
+}
+
+func (e *WaitTimeoutError) Is(target error) bool {
+	return target == ErrWaitTimeout
+}
+
+// retryOptions polls with the opts backoff while retry_if holds for the poll error.
+func (opts WaitOptions) retryOptions(ctx context.Context, retry_if retry.RetryIfFunc) []retry.Option {
+	delay_type := retry.BackOffDelay
+	if opts.Jitter > 0 {
+		delay_type = retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)
+	}
+
+	return []retry.Option{
+		retry.Context(ctx),
+		retry.Attempts(math.MaxUint32),
+		retry.LastErrorOnly(true),
+		retry.Delay(opts.InitialInterval),
+		retry.MaxDelay(opts.MaxInterval),
+		retry.MaxJitter(opts.Jitter),
+		retry.DelayType(delay_type),
+		retry.RetryIf(retry_if),
+	}
+}
+
+func (opts WaitOptions) report(ctx context.Context, result SbomPollResult) {
+	if opts.OnPoll != nil {
+		opts.OnPoll(result)
+	}
+	if opts.Progress != nil {
+		select {
+		case opts.Progress <- result:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// WaitforSbomFinishUploadWithOptions polls the sbom token with exponential backoff until the server finished processing.
+// Server errors and ErrUnknownToken are returned as is, a WaitTimeoutError is returned if the sbom is still processing after opts.Timeout.
+func (depClient *DepTrackClient) WaitforSbomFinishUploadWithOptions(ctx context.Context, sbom_uuid string, opts WaitOptions) (bool, error) {
+	wait_ctx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		wait_ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	var attempts uint
+	err := retry.Do(
+		func() error {
+			attempts++
+			state, err := depClient.GetBomProcessingStateContext(wait_ctx, sbom_uuid)
+			opts.report(wait_ctx, SbomPollResult{
+				Token:      sbom_uuid,
+				Attempt:    attempts,
+				Processing: state == BomStateProcessing,
+				State:      state,
+				Elapsed:    time.Since(start),
+				Err:        err,
+			})
+			if err != nil {
+				return err
+			}
+			switch state {
+			case BomStateProcessing:
+				return errSbomProcessing
+			case BomStateUnknown:
+				return ErrUnknownToken
+			}
+			return nil
+		},
+		opts.retryOptions(wait_ctx, func(err error) bool {
+			return errors.Is(err, errSbomProcessing)
+		})...,
+	)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(wait_ctx.Err(), context.DeadlineExceeded) {
+			return false, &WaitTimeoutError{Token: sbom_uuid, Attempts: attempts, Elapsed: time.Since(start)}
+		}
+		return false, err
+	}
+	// If not processing return true
+	return true, nil
+}
+
+// WaitForServerContext polls /version with the opts backoff until the server answers, e.g. while it starts up.
+// Connection errors and 5xx responses are retried, the last one is returned if the server still does not answer
+// after opts.Timeout. Other responses, e.g. a 404 of a wrong api path, are returned at once.
+func (depClient *DepTrackClient) WaitForServerContext(ctx context.Context, opts WaitOptions) error {
+	wait_ctx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		wait_ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	return retry.Do(
+		func() error {
+			req, err := http.NewRequestWithContext(wait_ctx, http.MethodGet, depClient.versionUrl(), nil)
+			if err != nil {
+				return err
+			}
+			resp, err := depClient.doRequest(req)
+			if err != nil {
+				return err
+			}
+			return resp.Body.Close()
+		},
+		opts.retryOptions(wait_ctx, func(err error) bool {
+			status_code := StatusCode(err)
+			return wait_ctx.Err() == nil && (status_code == 0 || status_code >= http.StatusInternalServerError)
+		})...,
+	)
+}
+
+func (depClient *DepTrackClient) WaitForServer(opts WaitOptions) error {
+	return depClient.WaitForServerContext(context.Background(), opts)
+}
@@ -9,9 +9,9 @@ require (
 	github.com/jackc/pgx/v4 v4.13.0 // indirect
 	github.com/package-url/packageurl-go v0.1.0
 	github.com/pkg/errors v0.9.1 // indirect
-	github.com/scribe-security/scribe/pkg/client v0.0.0-20210825115958-dde7d43fc90f
 	github.com/scribe-security/scribe/pkg/cyclonedx v0.0.0-20210825074943-2e54f501b1b4
 	github.com/sirupsen/logrus v1.8.1
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
 	gorm.io/driver/postgres v1.1.0
 	gorm.io/gorm v1.21.12
 	gotest.tools v2.2.0+incompatible
@@ -1,9 +1,395 @@
 package main
 
 import (
+	"context"
+	"deptrack/client"
+	"deptrack/osv"
+	"deptrack/reconcile"
+	"deptrack/report"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
 	_ "gorm.io/driver/postgres"
 )
 
+const (
+	DefaultApiServerPath = "http://localhost:8081/api/v1"
+	DefaultTeam          = "Scribe_backend"
+	DefaultApiKeyFile    = "scribe_backend_api_key"
+	DefaultServerWait    = time.Minute
+)
+
+var DefaultTeamPermissions = []string{
+	client.PermissionBomUpload,
+	client.PermissionProjectCreationUpload,
+	client.PermissionViewPortfolio,
+	client.PermissionAccessManagement,
+	client.PermissionPolicyManagement,
+	client.PermissionPolicyViolationAnalysis,
+	client.PermissionPortfolioManagement,
+	client.PermissionSystemConfiguration,
+	client.PermissionVulnerabilityAnalysis,
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n"+
+		"  provision     Setup the admin password, team, permissions and api key\n"+
+		"  reconcile     Plan or apply the server state described by a YAML spec\n"+
+		"  outdated      Report the sbom dependencies behind their latest version\n"+
+		"  blast-radius  Report the projects and components affected by a vulnerability\n"+
+		"  import-osv    Import a directory of OSV advisories as internal vulnerabilities\n"+
+		"  licenses      Report the sbom license compliance against the server license groups\n", os.Args[0])
+}
+
 func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "provision":
+		err = provision(os.Args[2:])
+	case "reconcile":
+		err = reconcileSpec(os.Args[2:])
+	case "outdated":
+		err = outdated(os.Args[2:])
+	case "blast-radius":
+		err = blastRadius(os.Args[2:])
+	case "import-osv":
+		err = importOSV(os.Args[2:])
+	case "licenses":
+		err = licenses(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func provision(args []string) error {
+	flags := flag.NewFlagSet("provision", flag.ExitOnError)
+	api_server_path := flags.String("url", DefaultApiServerPath, "Dependency track api server path")
+	team := flags.String("team", DefaultTeam, "Team to provision")
+	permissions := flags.String("permissions", strings.Join(DefaultTeamPermissions, ","), "Comma separated team permissions")
+	api_key_file := flags.String("api-key-file", DefaultApiKeyFile, "File the team api key export is written to, empty skips it")
+	wait := flags.Duration("wait", DefaultServerWait, "How long to wait for the api server to come up")
+	flags.Parse(args)
+
+	admin_password, ok := os.LookupEnv("DEPEND_TRACK_PASS")
+	if !ok {
+		return fmt.Errorf("DEPEND_TRACK_PASS is not set")
+	}
+
+	c, err := client.NewDepTrackClient("", *api_server_path)
+	if err != nil {
+		return err
+	}
+
+	wait_opts := client.DefaultWaitOptions
+	wait_opts.Timeout = *wait
+	if err := c.WaitForServer(wait_opts); err != nil {
+		return err
+	}
+
+	result, err := c.Provision(client.ProvisionConfig{
+		AdminUsername: os.Getenv("DEPEND_TRACK_USER"),
+		AdminPassword: admin_password,
+		Teams: []client.TeamConfig{
+			{Name: *team, Permissions: strings.Split(*permissions, ","), ApiKey: true},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Provision done, Changes: %d", len(result.Changes))
+
+	if *api_key_file == "" {
+		return nil
+	}
+	api_key, ok := result.GeneratedKeys[*team]
+	if !ok {
+		log.Warnf("Team %s already has an api key, %s is not written as the server only shows a key when it is generated", *team, *api_key_file)
+		return nil
+	}
+	content := "export API_KEY=" + api_key.Key
+	return ioutil.WriteFile(*api_key_file, []byte(content), 0600)
+}
+
+// DriftExitCode is returned by reconcile -detect-drift when the server does not match the spec.
+const DriftExitCode = 2
+
+func reconcileSpec(args []string) error {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	api_server_path := flags.String("url", DefaultApiServerPath, "Dependency track api server path")
+	spec_path := flags.String("spec", "", "YAML spec of the server state")
+	apply := flags.Bool("apply", false, "Apply the plan, without it the server is only read")
+	detect_drift := flags.Bool("detect-drift", false, "Read only, exit with status 2 if the server does not match the spec")
+	api_key_file := flags.String("api-key-file", "", "File the generated team api keys are written to, one team name and key per line")
+	flags.Parse(args)
+
+	if *spec_path == "" {
+		return fmt.Errorf("-spec is required")
+	}
+	if *apply && *detect_drift {
+		return fmt.Errorf("-apply and -detect-drift are exclusive")
+	}
+
+	api_key, ok := os.LookupEnv("API_KEY")
+	if !ok {
+		return fmt.Errorf("API_KEY is not set")
+	}
+
+	spec, err := reconcile.LoadSpec(*spec_path)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.NewDepTrackClient(api_key, *api_server_path)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	plan, err := reconcile.Compute(ctx, c, spec)
+	if err != nil {
+		return err
+	}
+	plan.Print(os.Stdout)
+
+	if *detect_drift && plan.HasChanges() {
+		os.Exit(DriftExitCode)
+	}
+	if !*apply || !plan.HasChanges() {
+		return nil
+	}
+
+	applied, err := plan.Apply(ctx)
+	log.Infof("Applied %d of %d changes", applied, len(plan.Changes))
+	// Keys generated before a failure are still written, the server does not show them again
+	if key_err := writeApiKeys(*api_key_file, plan.GeneratedKeys); key_err != nil && err == nil {
+		err = key_err
+	}
+	return err
+}
+
+// writeApiKeys writes the team name and key of the generated keys, sorted by team.
+func writeApiKeys(path string, keys map[string]client.ApiKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	teams := make([]string, 0, len(keys))
+	for team := range keys {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+	if path == "" {
+		log.Warnf("Api keys generated for teams %s are not written, set -api-key-file to keep them", strings.Join(teams, ", "))
+		return nil
+	}
+
+	var content strings.Builder
+	for _, team := range teams {
+		fmt.Fprintf(&content, "%s\t%s\n", team, keys[team].Key)
+	}
+	return ioutil.WriteFile(path, []byte(content.String()), 0600)
+}
+
+// readSbom decodes a CycloneDX JSON sbom.
+func readSbom(path string) (*cdx.BOM, error) {
+	sbom_file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer sbom_file.Close()
+
+	var bom cdx.BOM
+	if err := cdx.NewBOMDecoder(sbom_file, cdx.BOMFileFormatJSON).Decode(&bom); err != nil {
+		return nil, err
+	}
+	return &bom, nil
+}
+
+func outdated(args []string) error {
+	flags := flag.NewFlagSet("outdated", flag.ExitOnError)
+	api_server_path := flags.String("url", DefaultApiServerPath, "Dependency track api server path")
+	sbom_path := flags.String("sbom", "", "CycloneDX JSON sbom")
+	format := flags.String("format", string(report.FormatTable), "Report format, table, json, csv or markdown")
+	workers := flags.Int("workers", client.DefaultWalkOptions.Workers, "Concurrent latest version lookups")
+	flags.Parse(args)
+
+	if *sbom_path == "" {
+		return fmt.Errorf("-sbom is required")
+	}
+	report_format, err := report.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	api_key, ok := os.LookupEnv("API_KEY")
+	if !ok {
+		return fmt.Errorf("API_KEY is not set")
+	}
+
+	bom, err := readSbom(*sbom_path)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.NewDepTrackClient(api_key, *api_server_path)
+	if err != nil {
+		return err
+	}
+
+	opts := client.DefaultWalkOptions
+	opts.Workers = *workers
+	versions, err := c.GetPurlVersionsBySbomContext(context.Background(), bom, opts)
+	var walk_err *client.WalkError
+	if errors.As(err, &walk_err) {
+		log.Warnf("Latest version unknown for %d components", len(walk_err.Errors))
+	} else if err != nil {
+		return err
+	}
+
+	return report.NewOutdatedReport(versions).Write(os.Stdout, report_format)
+}
+
+func blastRadius(args []string) error {
+	flags := flag.NewFlagSet("blast-radius", flag.ExitOnError)
+	api_server_path := flags.String("url", DefaultApiServerPath, "Dependency track api server path")
+	source := flags.String("source", client.SourceNVD, "Vulnerability source, NVD, GITHUB, OSV, ...")
+	vuln_id := flags.String("id", "", "Vulnerability id, e.g. CVE-2021-44228")
+	format := flags.String("format", string(report.FormatTable), "Report format, table, json, csv or markdown")
+	workers := flags.Int("workers", client.DefaultWalkOptions.Workers, "Concurrent project lookups")
+	flags.Parse(args)
+
+	if *vuln_id == "" {
+		return fmt.Errorf("-id is required")
+	}
+	report_format, err := report.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	api_key, ok := os.LookupEnv("API_KEY")
+	if !ok {
+		return fmt.Errorf("API_KEY is not set")
+	}
+
+	c, err := client.NewDepTrackClient(api_key, *api_server_path)
+	if err != nil {
+		return err
+	}
+
+	opts := client.DefaultWalkOptions
+	opts.Workers = *workers
+	blast_radius, err := c.BlastRadiusContext(context.Background(), strings.ToUpper(*source), *vuln_id, opts)
+	var walk_err *client.WalkError
+	if errors.As(err, &walk_err) {
+		log.Warnf("Findings unknown for %d projects", len(walk_err.Errors))
+	} else if err != nil {
+		return err
+	}
+
+	return report.WriteBlastRadius(os.Stdout, blast_radius, report_format)
+}
+
+func importOSV(args []string) error {
+	flags := flag.NewFlagSet("import-osv", flag.ExitOnError)
+	api_server_path := flags.String("url", DefaultApiServerPath, "Dependency track api server path")
+	dir := flags.String("dir", "", "Directory of OSV JSON advisories")
+	dry_run := flags.Bool("dry-run", false, "Only print the changes, the server is only read")
+	flags.Parse(args)
+
+	if *dir == "" {
+		return fmt.Errorf("-dir is required")
+	}
+
+	api_key, ok := os.LookupEnv("API_KEY")
+	if !ok {
+		return fmt.Errorf("API_KEY is not set")
+	}
+
+	advisories, err := osv.LoadDir(*dir)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.NewDepTrackClient(api_key, *api_server_path)
+	if err != nil {
+		return err
+	}
+
+	changes, err := osv.ImportContext(context.Background(), c, advisories, osv.ImportOptions{DryRun: *dry_run})
+	for _, change := range changes {
+		fmt.Printf("%s %s\n", change.Action, change.VulnId)
+	}
+	log.Infof("Imported %d of %d advisories", len(changes), len(advisories))
+	return err
+}
+
+func licenses(args []string) error {
+	flags := flag.NewFlagSet("licenses", flag.ExitOnError)
+	api_server_path := flags.String("url", DefaultApiServerPath, "Dependency track api server path")
+	sbom_path := flags.String("sbom", "", "CycloneDX JSON sbom")
+	allowed := flags.String("allow", "", "Comma separated license groups of allowed licenses")
+	denied := flags.String("deny", "", "Comma separated license groups of denied licenses")
+	format := flags.String("format", string(report.FormatTable), "Report format, table, json, csv or markdown")
+	fail := flags.Bool("fail", false, "Exit with an error if a component has a denied license")
+	flags.Parse(args)
+
+	if *sbom_path == "" {
+		return fmt.Errorf("-sbom is required")
+	}
+	report_format, err := report.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	api_key, ok := os.LookupEnv("API_KEY")
+	if !ok {
+		return fmt.Errorf("API_KEY is not set")
+	}
+
+	bom, err := readSbom(*sbom_path)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.NewDepTrackClient(api_key, *api_server_path)
+	if err != nil {
+		return err
+	}
+
+	config := client.ComplianceConfig{}
+	if *allowed != "" {
+		config.Allowed = strings.Split(*allowed, ",")
+	}
+	if *denied != "" {
+		config.Denied = strings.Split(*denied, ",")
+	}
+	compliance, err := c.EvaluateLicenseCompliance(bom, config)
+	if err != nil {
+		return err
+	}
 
+	if err := report.WriteCompliance(os.Stdout, compliance, report_format); err != nil {
+		return err
+	}
+	if *fail && !compliance.Compliant() {
+		return fmt.Errorf("%d components with denied licenses", compliance.Counts[client.LicenseDenied])
+	}
+	return nil
 }
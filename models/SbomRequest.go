@@ -1,19 +1,31 @@
 package models
 
 import (
+	"context"
 	"deptrack/client"
+	"deptrack/webhook"
+	"errors"
 	"fmt"
 
+	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 var DB *gorm.DB
 
+const (
+	SbomStatusUploaded  = "uploaded"
+	SbomStatusProcessed = "processed"
+)
+
 type SbomRequest struct {
 	gorm.Model
 	Sbom_raw string
 	client.DepTrackSbomPostResponse
 	Status string
+	// ProjectUUID and ProjectName match BOM_PROCESSED notifications of servers not sending the token
+	ProjectUUID string
+	ProjectName string
 }
 
 func (p *SbomRequest) BeforeCreate(db *gorm.DB) error {
@@ -62,6 +74,51 @@ func GetSbomRequestByStatus(r *SbomRequest, status string) (err error) {
 	return nil
 }
 
+func GetSbomRequestByToken(r *SbomRequest, token string) (err error) {
+	err = DB.Where("token = ?", token).First(r).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+//get the oldest uploaded sbom request of the project by uuid, or by name when the uuid is not stored
+func GetUploadedSbomRequestByProject(r *SbomRequest, project_uuid string, project_name string) (err error) {
+	query := DB.Where("status = ?", SbomStatusUploaded)
+	if project_name != "" {
+		query = query.Where("project_uuid = ? OR (project_uuid = '' AND project_name = ?)", project_uuid, project_name)
+	} else {
+		query = query.Where("project_uuid = ?", project_uuid)
+	}
+	err = query.Order("id").First(r).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+//mark the sbom request of the processed upload token as processed,
+//older servers do not send the token and the oldest upload of the project is marked instead
+func SbomProcessedHandler(ctx context.Context, n *webhook.Notification, subject *webhook.BomSubject) error {
+	var r SbomRequest
+	var err error
+	if subject.Token != "" {
+		err = GetSbomRequestByToken(&r, subject.Token)
+	} else {
+		log.Debugf("BOM processed without token, matching by project, Project: %s", subject.Project.Name)
+		err = GetUploadedSbomRequestByProject(&r, subject.Project.UUID, subject.Project.Name)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	r.Status = SbomStatusProcessed
+	return UpdateSbomRequest(&r)
+}
+
 //update user
 func UpdateSbomRequest(r *SbomRequest) (err error) {
 	DB.Save(r)
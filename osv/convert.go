@@ -0,0 +1,217 @@
+package osv
+
+import (
+	"deptrack/client"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	packageurl "github.com/package-url/packageurl-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// ecosystemTypes maps OSV ecosystems to purl types.
+var ecosystemTypes = map[string]string{
+	"npm":       packageurl.TypeNPM,
+	"PyPI":      packageurl.TypePyPi,
+	"Maven":     packageurl.TypeMaven,
+	"Go":        packageurl.TypeGolang,
+	"crates.io": "cargo",
+	"NuGet":     packageurl.TypeNuget,
+	"RubyGems":  packageurl.TypeGem,
+	"Packagist": packageurl.TypeComposer,
+	"Debian":    "deb",
+	"Ubuntu":    "deb",
+	"Alpine":    "apk",
+	"Hex":       "hex",
+	"Pub":       "pub",
+}
+
+// ecosystemNamespaces holds the purl namespace of the distribution ecosystems.
+var ecosystemNamespaces = map[string]string{
+	"Debian": "debian",
+	"Ubuntu": "ubuntu",
+	"Alpine": "alpine",
+}
+
+// severities are the severities the server accepts, MODERATE is the GHSA name of MEDIUM.
+var severities = map[string]string{
+	"CRITICAL":   "CRITICAL",
+	"HIGH":       "HIGH",
+	"MODERATE":   "MEDIUM",
+	"MEDIUM":     "MEDIUM",
+	"LOW":        "LOW",
+	"INFO":       "INFO",
+	"UNASSIGNED": "UNASSIGNED",
+}
+
+// ParseSeverity maps a database_specific severity to a server severity, unknown ones are UNASSIGNED.
+func ParseSeverity(name string) string {
+	if severity, ok := severities[strings.ToUpper(name)]; ok {
+		return severity
+	}
+	return "UNASSIGNED"
+}
+
+// PackagePurl returns the versionless purl of the package, built from the ecosystem when the purl is not set.
+// Ecosystem releases, e.g. "Debian:11", are mapped by the ecosystem name.
+func (p Package) PackagePurl() (string, error) {
+	if p.Purl != "" {
+		purl, err := packageurl.FromString(p.Purl)
+		if err != nil {
+			return "", err
+		}
+		purl.Version = ""
+		return purl.ToString(), nil
+	}
+
+	ecosystem := strings.SplitN(p.Ecosystem, ":", 2)[0]
+	purl_type, ok := ecosystemTypes[ecosystem]
+	if !ok {
+		return "", fmt.Errorf("unsupported ecosystem %q", p.Ecosystem)
+	}
+
+	namespace := ecosystemNamespaces[ecosystem]
+	name := p.Name
+	separator := ""
+	switch purl_type {
+	case packageurl.TypeMaven:
+		separator = ":"
+	case packageurl.TypeNPM, packageurl.TypeGolang, packageurl.TypeComposer:
+		separator = "/"
+	}
+	if i := strings.LastIndex(name, separator); separator != "" && i >= 0 {
+		namespace, name = name[:i], name[i+1:]
+	}
+	return packageurl.NewPackageURL(purl_type, namespace, name, "", nil, "").ToString(), nil
+}
+
+// AffectedComponents converts the affected ranges and versions, GIT ranges are not supported and skipped.
+// Explicit versions are only used when the package has no version range.
+func (a Affected) AffectedComponents() ([]client.AffectedComponent, error) {
+	identity, err := a.Package.PackagePurl()
+	if err != nil {
+		return nil, err
+	}
+
+	var affected []client.AffectedComponent
+	for _, r := range a.Ranges {
+		if r.Type == RangeGit {
+			continue
+		}
+
+		var current *client.AffectedComponent
+		for _, event := range r.Events {
+			switch {
+			case event.Introduced != "":
+				// Ranges without an end event are affected up to the latest version
+				affected = append(affected, client.AffectedComponent{IdentityType: client.IdentityTypePurl, Identity: identity, VersionType: client.VersionTypeRange})
+				current = &affected[len(affected)-1]
+				if event.Introduced != "0" {
+					current.VersionStartIncluding = event.Introduced
+				}
+			case current == nil:
+				continue
+			case event.Fixed != "":
+				current.VersionEndExcluding = event.Fixed
+				current = nil
+			case event.LastAffected != "":
+				current.VersionEndIncluding = event.LastAffected
+				current = nil
+			case event.Limit != "":
+				current.VersionEndExcluding = event.Limit
+				current = nil
+			}
+		}
+	}
+	if len(affected) != 0 {
+		return affected, nil
+	}
+
+	for _, version := range a.Versions {
+		affected = append(affected, client.AffectedComponent{IdentityType: client.IdentityTypePurl, Identity: identity, VersionType: client.VersionTypeExact, Version: version})
+	}
+	return affected, nil
+}
+
+func millis(timestamp string) (int64, error) {
+	if timestamp == "" {
+		return 0, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return 0, err
+	}
+	return parsed.UnixNano() / int64(time.Millisecond), nil
+}
+
+// ToVulnerability converts the advisory into an INTERNAL vulnerability with the advisory id as vuln id.
+func (advisory *Advisory) ToVulnerability() (*client.Vulnraibility, error) {
+	if advisory.Id == "" {
+		return nil, fmt.Errorf("advisory without id")
+	}
+
+	vulnraibility := &client.Vulnraibility{
+		VulnId:      advisory.Id,
+		Source:      client.SourceInternal,
+		Title:       advisory.Summary,
+		Description: advisory.Details,
+	}
+	if vulnraibility.Description == "" {
+		vulnraibility.Description = advisory.Summary
+	}
+
+	var err error
+	if vulnraibility.Published, err = millis(advisory.Published); err != nil {
+		return nil, err
+	}
+	if vulnraibility.Updated, err = millis(advisory.Modified); err != nil {
+		return nil, err
+	}
+
+	for _, severity := range advisory.Severity {
+		switch severity.Type {
+		case SeverityCvssV2:
+			vulnraibility.CvssV2Vector = severity.Score
+		case SeverityCvssV3:
+			vulnraibility.CvssV3Vector = severity.Score
+		}
+	}
+
+	var references []string
+	for _, reference := range advisory.References {
+		references = append(references, fmt.Sprintf("* [%s](%s)", reference.Url, reference.Url))
+	}
+	vulnraibility.References = strings.Join(references, "\n")
+
+	var credits []string
+	for _, credit := range advisory.Credits {
+		credits = append(credits, credit.Name)
+	}
+	vulnraibility.Credits = strings.Join(credits, ", ")
+
+	if advisory.DatabaseSpecific != nil {
+		if advisory.DatabaseSpecific.Severity != "" {
+			vulnraibility.Severity = ParseSeverity(advisory.DatabaseSpecific.Severity)
+		}
+		for _, cwe_id := range advisory.DatabaseSpecific.CweIds {
+			id, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(cwe_id), "CWE-"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid cwe %q", cwe_id)
+			}
+			vulnraibility.Cwes = append(vulnraibility.Cwes, client.Cwe{CweId: id})
+		}
+	}
+
+	// A package that can not be mapped is skipped, the rest of the advisory is still imported
+	for _, affected := range advisory.Affected {
+		affected_components, err := affected.AffectedComponents()
+		if err != nil {
+			log.Warnf("Advisory %s, skipping affected package %s: %s", advisory.Id, affected.Package.Name, err)
+			continue
+		}
+		vulnraibility.AffectedComponents = append(vulnraibility.AffectedComponents, affected_components...)
+	}
+	return vulnraibility, nil
+}
@@ -0,0 +1,82 @@
+package osv
+
+import (
+	"context"
+	"deptrack/client"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+type ImportChange struct {
+	Action Action
+	VulnId string
+}
+
+type ImportOptions struct {
+	// DryRun only reads the server and reports the changes.
+	DryRun bool
+}
+
+// ImportContext creates or updates an INTERNAL vulnerability for every advisory,
+// withdrawn advisories are deleted. Vulnerabilities missing from the advisories are left untouched,
+// as are the ones already updated at the advisory modified time.
+func ImportContext(ctx context.Context, c *client.DepTrackClient, advisories []*Advisory, opts ImportOptions) ([]ImportChange, error) {
+	var changes []ImportChange
+	for _, advisory := range advisories {
+		vulnraibility, err := advisory.ToVulnerability()
+		if err != nil {
+			return changes, fmt.Errorf("advisory %s: %w", advisory.Id, err)
+		}
+
+		existing, err := c.GetVulnerabilityContext(ctx, client.SourceInternal, advisory.Id)
+		if client.IsNotFound(err) {
+			existing = nil
+		} else if err != nil {
+			return changes, err
+		}
+
+		var change ImportChange
+		switch {
+		case advisory.Withdrawn != "" && existing == nil:
+			continue
+		case advisory.Withdrawn != "":
+			change = ImportChange{Action: ActionDelete, VulnId: advisory.Id}
+			if !opts.DryRun {
+				err = c.DeleteInternalVulnerabilityContext(ctx, existing.UUID)
+			}
+		case existing == nil:
+			change = ImportChange{Action: ActionCreate, VulnId: advisory.Id}
+			if !opts.DryRun {
+				_, err = c.CreateInternalVulnerabilityContext(ctx, vulnraibility)
+			}
+		case vulnraibility.Updated != 0 && vulnraibility.Updated == existing.Updated:
+			log.Debugf("Advisory unchanged, Id: %s", advisory.Id)
+			continue
+		default:
+			change = ImportChange{Action: ActionUpdate, VulnId: advisory.Id}
+			vulnraibility.UUID = existing.UUID
+			if !opts.DryRun {
+				_, err = c.UpdateInternalVulnerabilityContext(ctx, vulnraibility)
+			}
+		}
+		if err != nil {
+			return changes, fmt.Errorf("%s advisory %s: %w", change.Action, advisory.Id, err)
+		}
+		log.Debugf("Advisory imported, Id: %s Action: %s", change.VulnId, change.Action)
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func Import(c *client.DepTrackClient, advisories []*Advisory, opts ImportOptions) ([]ImportChange, error) {
+	return ImportContext(context.Background(), c, advisories, opts)
+}
@@ -0,0 +1,138 @@
+/*
+Package osv imports advisories in the OSV format as dependency track INTERNAL vulnerabilities,
+making a directory of advisories the source of truth for private vulnerabilities.
+*/
+package osv
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OSV severity, range and event types, see https://ossf.github.io/osv-schema
+const (
+	SeverityCvssV2 = "CVSS_V2"
+	SeverityCvssV3 = "CVSS_V3"
+	RangeSemver    = "SEMVER"
+	RangeEcosystem = "ECOSYSTEM"
+	RangeGit       = "GIT"
+)
+
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+type Range struct {
+	Type   string  `json:"type"`
+	Repo   string  `json:"repo,omitempty"`
+	Events []Event `json:"events"`
+}
+
+type Affected struct {
+	Package  Package  `json:"package"`
+	Ranges   []Range  `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+type Reference struct {
+	Type string `json:"type"`
+	Url  string `json:"url"`
+}
+
+type Credit struct {
+	Name string `json:"name"`
+}
+
+// DatabaseSpecific holds the fields GHSA style advisories put under database_specific.
+type DatabaseSpecific struct {
+	Severity string   `json:"severity,omitempty"`
+	CweIds   []string `json:"cwe_ids,omitempty"`
+}
+
+type Advisory struct {
+	SchemaVersion    string            `json:"schema_version,omitempty"`
+	Id               string            `json:"id"`
+	Modified         string            `json:"modified"`
+	Published        string            `json:"published,omitempty"`
+	Withdrawn        string            `json:"withdrawn,omitempty"`
+	Aliases          []string          `json:"aliases,omitempty"`
+	Summary          string            `json:"summary,omitempty"`
+	Details          string            `json:"details,omitempty"`
+	Severity         []Severity        `json:"severity,omitempty"`
+	Affected         []Affected        `json:"affected,omitempty"`
+	References       []Reference       `json:"references,omitempty"`
+	Credits          []Credit          `json:"credits,omitempty"`
+	DatabaseSpecific *DatabaseSpecific `json:"database_specific,omitempty"`
+}
+
+func Load(path string) (*Advisory, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var advisory Advisory
+	if err := json.Unmarshal(raw, &advisory); err != nil {
+		return nil, err
+	}
+	return &advisory, nil
+}
+
+// LoadDir loads the *.json advisories of dir and its sub directories, ordered by path.
+func LoadDir(dir string) ([]*Advisory, error) {
+	var paths []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.HasSuffix(info.Name(), ".json") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	advisories := make([]*Advisory, 0, len(paths))
+	for _, path := range paths {
+		advisory, err := Load(path)
+		if err != nil {
+			return nil, &LoadError{Path: path, Err: err}
+		}
+		advisories = append(advisories, advisory)
+	}
+	return advisories, nil
+}
+
+type LoadError struct {
+	Path string
+	Err  error
+}
+
+func (e *LoadError) Error() string {
+	return "load " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,370 @@
+package reconcile
+
+import (
+	"context"
+	"deptrack/client"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	KindTeam             = "team"
+	KindPolicy           = "policy"
+	KindNotificationRule = "notification rule"
+	KindRepository       = "repository"
+	KindConfigProperty   = "config property"
+	EncryptedStringType  = "ENCRYPTEDSTRING"
+)
+
+func sameStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sorted_a := append([]string{}, a...)
+	sorted_b := append([]string{}, b...)
+	sort.Strings(sorted_a)
+	sort.Strings(sorted_b)
+	for i := range sorted_a {
+		if sorted_a[i] != sorted_b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func diffTeams(plan *Plan, c *client.DepTrackClient, state *State, spec *Spec) {
+	for _, team_spec := range spec.Teams {
+		team := state.Teams.FindByName(team_spec.Name)
+		changes := client.PlanTeam(team, team_spec)
+		if team == nil {
+			detail := "permissions " + strings.Join(team_spec.Permissions, ", ")
+			if team_spec.ApiKey {
+				detail += ", api key"
+			}
+			plan.add(ActionCreate, KindTeam, team_spec.Name, detail, func(ctx context.Context) error {
+				var created *client.Team
+				for _, change := range changes {
+					var err error
+					if created, err = plan.applyTeamChange(ctx, c, created, change); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			continue
+		}
+
+		current := *team
+		for _, change := range changes {
+			change := change
+			plan.add(ActionUpdate, KindTeam, team.Name, change.String(), func(ctx context.Context) error {
+				_, err := plan.applyTeamChange(ctx, c, &current, change)
+				return err
+			})
+		}
+	}
+}
+
+func findPolicy(policy_list client.PolicyList, name string) *client.Policy {
+	for i := range policy_list {
+		if policy_list[i].Name == name {
+			return &policy_list[i]
+		}
+	}
+	return nil
+}
+
+func conditionString(subject string, operator string, value string) string {
+	return fmt.Sprintf("%s %s %s", subject, operator, value)
+}
+
+func diffPolicies(plan *Plan, c *client.DepTrackClient, state *State, spec *Spec) {
+	for _, policy_spec := range spec.Policies {
+		policy_spec := policy_spec
+		policy := findPolicy(state.Policies, policy_spec.Name)
+		if policy == nil {
+			detail := fmt.Sprintf("%s %s, %d conditions", policy_spec.ViolationState, policy_spec.Operator, len(policy_spec.Conditions))
+			plan.add(ActionCreate, KindPolicy, policy_spec.Name, detail, func(ctx context.Context) error {
+				created, err := c.CreatePolicyContext(ctx, &client.Policy{Name: policy_spec.Name, Operator: policy_spec.Operator, ViolationState: policy_spec.ViolationState})
+				if err != nil {
+					return err
+				}
+				for _, condition := range policy_spec.Conditions {
+					if _, err := c.CreatePolicyConditionContext(ctx, created.UUID, &client.PolicyCondition{Subject: condition.Subject, Operator: condition.Operator, Value: condition.Value}); err != nil {
+						return err
+					}
+				}
+				for _, tag := range policy_spec.Tags {
+					if err := c.AddPolicyTagContext(ctx, created.UUID, tag); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			continue
+		}
+
+		policy_uuid := policy.UUID
+		if policy.Operator != policy_spec.Operator || policy.ViolationState != policy_spec.ViolationState {
+			detail := fmt.Sprintf("%s %s -> %s %s", policy.ViolationState, policy.Operator, policy_spec.ViolationState, policy_spec.Operator)
+			updated := *policy
+			updated.Operator = policy_spec.Operator
+			updated.ViolationState = policy_spec.ViolationState
+			updated.PolicyConditions = nil
+			plan.add(ActionUpdate, KindPolicy, policy.Name, detail, func(ctx context.Context) error {
+				_, err := c.UpdatePolicyContext(ctx, &updated)
+				return err
+			})
+		}
+
+		current_conditions := make(map[string]string)
+		for _, condition := range policy.PolicyConditions {
+			current_conditions[conditionString(condition.Subject, condition.Operator, condition.Value)] = condition.UUID
+		}
+		wanted_conditions := make(map[string]bool)
+		for _, condition := range policy_spec.Conditions {
+			condition := condition
+			key := conditionString(condition.Subject, condition.Operator, condition.Value)
+			wanted_conditions[key] = true
+			if _, ok := current_conditions[key]; ok {
+				continue
+			}
+			plan.add(ActionUpdate, KindPolicy, policy.Name, "add condition "+key, func(ctx context.Context) error {
+				_, err := c.CreatePolicyConditionContext(ctx, policy_uuid, &client.PolicyCondition{Subject: condition.Subject, Operator: condition.Operator, Value: condition.Value})
+				return err
+			})
+		}
+		for _, condition := range policy.PolicyConditions {
+			condition_uuid := condition.UUID
+			key := conditionString(condition.Subject, condition.Operator, condition.Value)
+			if wanted_conditions[key] {
+				continue
+			}
+			plan.add(ActionDelete, KindPolicy, policy.Name, "remove condition "+key, func(ctx context.Context) error {
+				return c.DeletePolicyConditionContext(ctx, condition_uuid)
+			})
+		}
+
+		for _, tag := range policy_spec.Tags {
+			tag := tag
+			if client.HasTag(policy.Tags, tag) {
+				continue
+			}
+			plan.add(ActionUpdate, KindPolicy, policy.Name, "add tag "+tag, func(ctx context.Context) error {
+				return c.AddPolicyTagContext(ctx, policy_uuid, tag)
+			})
+		}
+		for _, tag := range policy.Tags {
+			tag_name := tag.Name
+			if client.HasTag(client.NewTags(policy_spec.Tags...), tag_name) {
+				continue
+			}
+			plan.add(ActionDelete, KindPolicy, policy.Name, "remove tag "+tag_name, func(ctx context.Context) error {
+				return c.RemovePolicyTagContext(ctx, policy_uuid, tag_name)
+			})
+		}
+	}
+}
+
+func findPublisher(publisher_list client.NotificationPublisherList, name string) *client.NotificationPublisher {
+	for i := range publisher_list {
+		if publisher_list[i].Name == name {
+			return &publisher_list[i]
+		}
+	}
+	return nil
+}
+
+func findNotificationRule(rule_list client.NotificationRuleList, name string) *client.NotificationRule {
+	for i := range rule_list {
+		if rule_list[i].Name == name {
+			return &rule_list[i]
+		}
+	}
+	return nil
+}
+
+func diffNotificationRules(plan *Plan, c *client.DepTrackClient, state *State, spec *Spec) error {
+	for _, rule_spec := range spec.NotificationRules {
+		publisher := findPublisher(state.Publishers, rule_spec.Publisher)
+		if publisher == nil {
+			return fmt.Errorf("notification rule %s: unknown publisher %s", rule_spec.Name, rule_spec.Publisher)
+		}
+
+		wanted := client.NotificationRule{
+			Name:              rule_spec.Name,
+			Enabled:           enabled(rule_spec.Enabled),
+			NotifyChildren:    rule_spec.NotifyChildren,
+			Scope:             rule_spec.Scope,
+			NotificationLevel: rule_spec.Level,
+			NotifyOn:          rule_spec.NotifyOn,
+			Publisher:         &client.NotificationPublisher{UUID: publisher.UUID, Name: publisher.Name},
+			PublisherConfig:   rule_spec.PublisherConfig,
+		}
+
+		create_detail := fmt.Sprintf("%s %s via %s on %s", wanted.Scope, wanted.NotificationLevel, publisher.Name, strings.Join(wanted.NotifyOn, ", "))
+		rule := findNotificationRule(state.NotificationRules, rule_spec.Name)
+		if rule == nil {
+			plan.add(ActionCreate, KindNotificationRule, wanted.Name, create_detail, func(ctx context.Context) error {
+				return createNotificationRule(ctx, c, wanted, nil)
+			})
+			continue
+		}
+
+		// The server does not update the scope or publisher of a rule, the rule is replaced keeping its projects
+		var replaced []string
+		if rule.Scope != wanted.Scope {
+			replaced = append(replaced, fmt.Sprintf("scope %s -> %s", rule.Scope, wanted.Scope))
+		}
+		if rule.Publisher == nil || rule.Publisher.UUID != publisher.UUID {
+			current_publisher := ""
+			if rule.Publisher != nil {
+				current_publisher = rule.Publisher.Name
+			}
+			replaced = append(replaced, fmt.Sprintf("publisher %s -> %s", current_publisher, publisher.Name))
+		}
+		if len(replaced) != 0 {
+			rule_uuid := rule.UUID
+			projects := rule.Projects
+			plan.add(ActionDelete, KindNotificationRule, wanted.Name, "replace, "+strings.Join(replaced, ", "), func(ctx context.Context) error {
+				return c.DeleteNotificationRuleContext(ctx, rule_uuid)
+			})
+			plan.add(ActionCreate, KindNotificationRule, wanted.Name, create_detail, func(ctx context.Context) error {
+				return createNotificationRule(ctx, c, wanted, projects)
+			})
+			continue
+		}
+
+		var diffs []string
+		if rule.Enabled != wanted.Enabled {
+			diffs = append(diffs, fmt.Sprintf("enabled %t -> %t", rule.Enabled, wanted.Enabled))
+		}
+		if rule.NotifyChildren != wanted.NotifyChildren {
+			diffs = append(diffs, fmt.Sprintf("notifyChildren %t -> %t", rule.NotifyChildren, wanted.NotifyChildren))
+		}
+		if rule.NotificationLevel != wanted.NotificationLevel {
+			diffs = append(diffs, fmt.Sprintf("level %s -> %s", rule.NotificationLevel, wanted.NotificationLevel))
+		}
+		if !sameStrings(rule.NotifyOn, wanted.NotifyOn) {
+			diffs = append(diffs, fmt.Sprintf("notifyOn [%s] -> [%s]", strings.Join(rule.NotifyOn, ", "), strings.Join(wanted.NotifyOn, ", ")))
+		}
+		if rule.PublisherConfig != wanted.PublisherConfig {
+			diffs = append(diffs, "publisherConfig")
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+
+		wanted.UUID = rule.UUID
+		wanted.Projects = rule.Projects
+		plan.add(ActionUpdate, KindNotificationRule, wanted.Name, strings.Join(diffs, ", "), func(ctx context.Context) error {
+			_, err := c.UpdateNotificationRuleContext(ctx, &wanted)
+			return err
+		})
+	}
+	return nil
+}
+
+// createNotificationRule creates the rule, sets the fields the create ignores and limits it to projects.
+func createNotificationRule(ctx context.Context, c *client.DepTrackClient, rule client.NotificationRule, projects []client.ProjectRef) error {
+	created, err := c.CreateNotificationRuleContext(ctx, &rule)
+	if err != nil {
+		return err
+	}
+	rule.UUID = created.UUID
+	if _, err := c.UpdateNotificationRuleContext(ctx, &rule); err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if _, err := c.AddNotificationRuleProjectContext(ctx, rule.UUID, project.UUID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findRepository(repository_list client.RepositoryList, repository_type string, identifier string) *client.Repository {
+	for i := range repository_list {
+		if repository_list[i].Type == repository_type && repository_list[i].Identifier == identifier {
+			return &repository_list[i]
+		}
+	}
+	return nil
+}
+
+func diffRepositories(plan *Plan, c *client.DepTrackClient, state *State, spec *Spec) {
+	for _, repository_spec := range spec.Repositories {
+		name := repository_spec.Type + "/" + repository_spec.Identifier
+		wanted := client.Repository{
+			Type:            repository_spec.Type,
+			Identifier:      repository_spec.Identifier,
+			Url:             repository_spec.Url,
+			ResolutionOrder: repository_spec.ResolutionOrder,
+			Enabled:         enabled(repository_spec.Enabled),
+			Internal:        repository_spec.Internal,
+		}
+
+		repository := findRepository(state.Repositories, repository_spec.Type, repository_spec.Identifier)
+		if repository == nil {
+			plan.add(ActionCreate, KindRepository, name, wanted.Url, func(ctx context.Context) error {
+				_, err := c.CreateRepositoryContext(ctx, &wanted)
+				return err
+			})
+			continue
+		}
+
+		var diffs []string
+		if repository.Url != wanted.Url {
+			diffs = append(diffs, fmt.Sprintf("url %s -> %s", repository.Url, wanted.Url))
+		}
+		if repository.ResolutionOrder != wanted.ResolutionOrder {
+			diffs = append(diffs, fmt.Sprintf("resolutionOrder %d -> %d", repository.ResolutionOrder, wanted.ResolutionOrder))
+		}
+		if repository.Enabled != wanted.Enabled {
+			diffs = append(diffs, fmt.Sprintf("enabled %t -> %t", repository.Enabled, wanted.Enabled))
+		}
+		if repository.Internal != wanted.Internal {
+			diffs = append(diffs, fmt.Sprintf("internal %t -> %t", repository.Internal, wanted.Internal))
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+
+		wanted.UUID = repository.UUID
+		wanted.AuthenticationRequired = repository.AuthenticationRequired
+		wanted.Username = repository.Username
+		plan.add(ActionUpdate, KindRepository, name, strings.Join(diffs, ", "), func(ctx context.Context) error {
+			_, err := c.UpdateRepositoryContext(ctx, &wanted)
+			return err
+		})
+	}
+}
+
+func diffConfigProperties(plan *Plan, c *client.DepTrackClient, state *State, spec *Spec) error {
+	for _, property_spec := range spec.ConfigProperties {
+		name := property_spec.Group + "/" + property_spec.Name
+		property := state.ConfigProperties.Find(property_spec.Group, property_spec.Name)
+		if property == nil {
+			return fmt.Errorf("config property %s does not exist", name)
+		}
+		if property.PropertyType == EncryptedStringType {
+			log.Warnf("Config property %s is encrypted, skipping", name)
+			continue
+		}
+		if property.PropertyValue == property_spec.Value {
+			continue
+		}
+
+		wanted := *property
+		wanted.PropertyValue = property_spec.Value
+		detail := fmt.Sprintf("%q -> %q", property.PropertyValue, property_spec.Value)
+		plan.add(ActionUpdate, KindConfigProperty, name, detail, func(ctx context.Context) error {
+			_, err := c.UpdateConfigPropertyContext(ctx, &wanted)
+			return err
+		})
+	}
+	return nil
+}
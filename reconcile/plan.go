@@ -0,0 +1,166 @@
+package reconcile
+
+import (
+	"context"
+	"deptrack/client"
+	"fmt"
+	"io"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "+"
+	ActionUpdate Action = "~"
+	ActionDelete Action = "-"
+)
+
+// Change is a single planned modification of the server.
+type Change struct {
+	Action Action
+	Kind   string
+	Name   string
+	Detail string
+	apply  func(ctx context.Context) error
+}
+
+func (change Change) String() string {
+	line := fmt.Sprintf("%s %s %s", change.Action, change.Kind, change.Name)
+	if change.Detail != "" {
+		line += ": " + change.Detail
+	}
+	return line
+}
+
+type Plan struct {
+	Changes []Change
+	// GeneratedKeys holds the api keys generated by Apply by team name,
+	// newer servers only show a key in full when it is created.
+	GeneratedKeys map[string]client.ApiKey
+}
+
+func (plan *Plan) add(action Action, kind string, name string, detail string, apply func(ctx context.Context) error) {
+	plan.Changes = append(plan.Changes, Change{Action: action, Kind: kind, Name: name, Detail: detail, apply: apply})
+}
+
+// applyTeamChange applies change to team and keeps the api key it generates.
+func (plan *Plan) applyTeamChange(ctx context.Context, c *client.DepTrackClient, team *client.Team, change client.TeamChange) (*client.Team, error) {
+	team, err := c.ApplyTeamChangeContext(ctx, team, change)
+	if err != nil {
+		return nil, err
+	}
+	if change.Kind == client.TeamGenerateApiKey {
+		if plan.GeneratedKeys == nil {
+			plan.GeneratedKeys = make(map[string]client.ApiKey)
+		}
+		plan.GeneratedKeys[team.Name] = team.ApiKeys[len(team.ApiKeys)-1]
+	}
+	return team, nil
+}
+
+// HasChanges reports drift between the server and the spec.
+func (plan *Plan) HasChanges() bool {
+	return len(plan.Changes) != 0
+}
+
+func (plan *Plan) count(action Action) int {
+	count := 0
+	for _, change := range plan.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Print writes the plan in a terraform plan like format.
+func (plan *Plan) Print(w io.Writer) {
+	if !plan.HasChanges() {
+		fmt.Fprintln(w, "No changes. The server matches the spec.")
+		return
+	}
+
+	for _, change := range plan.Changes {
+		fmt.Fprintf(w, "  %s\n", change)
+	}
+	fmt.Fprintf(w, "\nPlan: %d to add, %d to change, %d to destroy.\n", plan.count(ActionCreate), plan.count(ActionUpdate), plan.count(ActionDelete))
+}
+
+// Apply runs the changes in order and stops on the first failure, the number of applied changes is returned.
+func (plan *Plan) Apply(ctx context.Context) (int, error) {
+	for i, change := range plan.Changes {
+		if err := change.apply(ctx); err != nil {
+			return i, fmt.Errorf("%s: %w", change, err)
+		}
+	}
+	return len(plan.Changes), nil
+}
+
+// State is the live server state the spec is compared against.
+type State struct {
+	Teams             client.TeamList
+	Policies          client.PolicyList
+	Publishers        client.NotificationPublisherList
+	NotificationRules client.NotificationRuleList
+	Repositories      client.RepositoryList
+	ConfigProperties  client.ConfigPropertyList
+}
+
+// FetchState reads only the resource kinds used by the spec.
+func FetchState(ctx context.Context, c *client.DepTrackClient, spec *Spec) (*State, error) {
+	state := &State{}
+	var err error
+	if len(spec.Teams) != 0 {
+		if state.Teams, err = c.GetTeamsContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if len(spec.Policies) != 0 {
+		if state.Policies, err = c.GetPoliciesContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if len(spec.NotificationRules) != 0 {
+		if state.Publishers, err = c.GetNotificationPublishersContext(ctx); err != nil {
+			return nil, err
+		}
+		if state.NotificationRules, err = c.GetNotificationRulesContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if len(spec.Repositories) != 0 {
+		if state.Repositories, err = c.GetRepositoriesContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if len(spec.ConfigProperties) != 0 {
+		if state.ConfigProperties, err = c.GetConfigPropertiesContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// Compute fetches the server state and plans the changes converging it to spec.
+func Compute(ctx context.Context, c *client.DepTrackClient, spec *Spec) (*Plan, error) {
+	state, err := FetchState(ctx, c, spec)
+	if err != nil {
+		return nil, err
+	}
+	return Diff(c, state, spec)
+}
+
+// Diff plans the changes converging state to spec, c is only used when the plan is applied.
+func Diff(c *client.DepTrackClient, state *State, spec *Spec) (*Plan, error) {
+	plan := &Plan{}
+	diffTeams(plan, c, state, spec)
+	diffPolicies(plan, c, state, spec)
+	if err := diffNotificationRules(plan, c, state, spec); err != nil {
+		return nil, err
+	}
+	diffRepositories(plan, c, state, spec)
+	if err := diffConfigProperties(plan, c, state, spec); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
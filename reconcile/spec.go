@@ -0,0 +1,83 @@
+/*
+Package reconcile converges a dependency track server to a declarative YAML spec.
+
+Resources are matched by name (repositories by type and identifier), resources missing from
+the spec are left untouched. Within a managed team or policy the spec is exact, permissions,
+conditions and tags not listed are removed.
+*/
+package reconcile
+
+import (
+	"deptrack/client"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v3"
+)
+
+type PolicyConditionSpec struct {
+	Subject  string `yaml:"subject"`
+	Operator string `yaml:"operator"`
+	Value    string `yaml:"value"`
+}
+
+type PolicySpec struct {
+	Name           string                `yaml:"name"`
+	Operator       string                `yaml:"operator"`
+	ViolationState string                `yaml:"violationState"`
+	Conditions     []PolicyConditionSpec `yaml:"conditions"`
+	Tags           []string              `yaml:"tags"`
+}
+
+type NotificationRuleSpec struct {
+	Name            string   `yaml:"name"`
+	Scope           string   `yaml:"scope"`
+	Level           string   `yaml:"level"`
+	Publisher       string   `yaml:"publisher"`
+	PublisherConfig string   `yaml:"publisherConfig"`
+	NotifyOn        []string `yaml:"notifyOn"`
+	NotifyChildren  bool     `yaml:"notifyChildren"`
+	// Enabled defaults to true
+	Enabled *bool `yaml:"enabled"`
+}
+
+type RepositorySpec struct {
+	Type            string `yaml:"type"`
+	Identifier      string `yaml:"identifier"`
+	Url             string `yaml:"url"`
+	ResolutionOrder int    `yaml:"resolutionOrder"`
+	Internal        bool   `yaml:"internal"`
+	// Enabled defaults to true
+	Enabled *bool `yaml:"enabled"`
+}
+
+// ConfigPropertySpec sets an existing config property, encrypted properties can not be read back and are skipped.
+type ConfigPropertySpec struct {
+	Group string `yaml:"group"`
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type Spec struct {
+	Teams             []client.TeamConfig    `yaml:"teams"`
+	Policies          []PolicySpec           `yaml:"policies"`
+	NotificationRules []NotificationRuleSpec `yaml:"notificationRules"`
+	Repositories      []RepositorySpec       `yaml:"repositories"`
+	ConfigProperties  []ConfigPropertySpec   `yaml:"configProperties"`
+}
+
+func LoadSpec(path string) (*Spec, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+func enabled(value *bool) bool {
+	return value == nil || *value
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"deptrack/client"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+// WriteBlastRadius renders the projects and components affected by a vulnerability in format.
+func WriteBlastRadius(w io.Writer, blast_radius *client.BlastRadius, format Format) error {
+	switch format {
+	case FormatTable:
+		return writeBlastRadiusTable(w, blast_radius)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(blast_radius)
+	case FormatCSV:
+		return writeBlastRadiusCSV(w, blast_radius)
+	case FormatMarkdown:
+		return writeBlastRadiusMarkdown(w, blast_radius)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+func blastRadiusSummary(blast_radius *client.BlastRadius) string {
+	vulnerability := blast_radius.Vulnerability
+	return fmt.Sprintf("%s %s %s, %d projects, %d components", vulnerability.Source, vulnerability.VulnId,
+		vulnerability.Severity, blast_radius.Projects, len(blast_radius.Entries))
+}
+
+func writeBlastRadiusTable(w io.Writer, blast_radius *client.BlastRadius) error {
+	if _, err := fmt.Fprintf(w, "%s\n\n", blastRadiusSummary(blast_radius)); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROJECT\tVERSION\tPURL\tANALYSIS\tSUPPRESSED")
+	for _, entry := range blast_radius.Entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\n", entry.ProjectName, entry.ProjectVersion, entry.Purl, entry.State, entry.Suppressed)
+	}
+	return tw.Flush()
+}
+
+func writeBlastRadiusCSV(w io.Writer, blast_radius *client.BlastRadius) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"project", "project_uuid", "version", "component_uuid", "purl", "analysis", "suppressed"})
+	for _, entry := range blast_radius.Entries {
+		writer.Write([]string{entry.ProjectName, entry.Project, entry.ProjectVersion, entry.Component, entry.Purl,
+			string(entry.State), strconv.FormatBool(entry.Suppressed)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeBlastRadiusMarkdown(w io.Writer, blast_radius *client.BlastRadius) error {
+	fmt.Fprintf(w, "# Blast radius\n\n%s\n\n", blastRadiusSummary(blast_radius))
+	fmt.Fprintln(w, "| Project | Version | Purl | Analysis | Suppressed |")
+	fmt.Fprintln(w, "| --- | --- | --- | --- | --- |")
+	for _, entry := range blast_radius.Entries {
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %t |\n", markdownEscape(entry.ProjectName), markdownEscape(entry.ProjectVersion),
+			markdownEscape(entry.Purl), entry.State, entry.Suppressed)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"deptrack/client"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+var complianceStatuses = []client.LicenseStatus{client.LicenseDenied, client.LicenseUnknown, client.LicenseMissing, client.LicenseAllowed}
+
+// WriteCompliance renders the license compliance of the sbom components in format, the most severe statuses first.
+func WriteCompliance(w io.Writer, compliance *client.ComplianceReport, format Format) error {
+	switch format {
+	case FormatTable:
+		return writeComplianceTable(w, compliance)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(compliance)
+	case FormatCSV:
+		return writeComplianceCSV(w, compliance)
+	case FormatMarkdown:
+		return writeComplianceMarkdown(w, compliance)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+// byStatus lists the components of a status.
+func byStatus(compliance *client.ComplianceReport, status client.LicenseStatus) []client.ComponentCompliance {
+	var components []client.ComponentCompliance
+	for _, component := range compliance.Components {
+		if component.Status == status {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+func complianceSummary(compliance *client.ComplianceReport) string {
+	counts := make([]string, 0, len(complianceStatuses))
+	for _, status := range complianceStatuses {
+		counts = append(counts, fmt.Sprintf("%d %s", compliance.Counts[status], status))
+	}
+	return strings.Join(counts, ", ")
+}
+
+func writeComplianceTable(w io.Writer, compliance *client.ComplianceReport) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tNAME\tVERSION\tLICENSES\tGROUPS")
+	for _, status := range complianceStatuses {
+		for _, component := range byStatus(compliance, status) {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", component.Status, component.Name, component.Version,
+				component.Licenses, strings.Join(component.Groups, ", "))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", complianceSummary(compliance))
+	return err
+}
+
+func writeComplianceCSV(w io.Writer, compliance *client.ComplianceReport) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"status", "name", "version", "purl", "licenses", "groups"})
+	for _, status := range complianceStatuses {
+		for _, component := range byStatus(compliance, status) {
+			writer.Write([]string{string(component.Status), component.Name, component.Version, component.Purl,
+				component.Licenses, strings.Join(component.Groups, ";")})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeComplianceMarkdown(w io.Writer, compliance *client.ComplianceReport) error {
+	fmt.Fprintf(w, "# License compliance\n\n%s\n", complianceSummary(compliance))
+	for _, status := range complianceStatuses {
+		components := byStatus(compliance, status)
+		if len(components) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n## %s (%d)\n\n", status, len(components))
+		fmt.Fprintln(w, "| Name | Version | Licenses | Groups |")
+		fmt.Fprintln(w, "| --- | --- | --- | --- |")
+		for _, component := range components {
+			fmt.Fprintf(w, "| %s | %s | %s | %s |\n", markdownEscape(component.Name), markdownEscape(component.Version),
+				markdownEscape(component.Licenses), markdownEscape(strings.Join(component.Groups, ", ")))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+/*
+Package report renders reports on top of the dependency track client results.
+*/
+package report
+
+import (
+	"deptrack/client"
+	"deptrack/versioning"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+type Format string
+
+const (
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "markdown"
+)
+
+// ParseFormat validates a format name.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case FormatTable, FormatJSON, FormatCSV, FormatMarkdown:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown report format %q", name)
+}
+
+const hoursPerYear = 24 * 365.25
+
+// upgradeOrder sorts the groups of an ecosystem from the riskiest upgrade.
+var upgradeOrder = map[versioning.UpgradeClass]int{
+	versioning.UpgradeMajor:      0,
+	versioning.UpgradeMinor:      1,
+	versioning.UpgradePatch:      2,
+	versioning.UpgradePrerelease: 3,
+	versioning.UpgradeUnknown:    4,
+}
+
+type OutdatedDependency struct {
+	Purl      string                  `json:"purl"`
+	Ecosystem string                  `json:"ecosystem"`
+	Name      string                  `json:"name"`
+	Current   string                  `json:"current"`
+	Latest    string                  `json:"latest"`
+	Upgrade   versioning.UpgradeClass `json:"upgrade"`
+	// Libyears is the time between the current and latest release, nil when either release date is unknown
+	Libyears *float64 `json:"libyears,omitempty"`
+}
+
+type OutdatedGroup struct {
+	Ecosystem    string                  `json:"ecosystem"`
+	Upgrade      versioning.UpgradeClass `json:"upgrade"`
+	Libyears     float64                 `json:"libyears"`
+	Dependencies []OutdatedDependency    `json:"dependencies"`
+}
+
+type OutdatedReport struct {
+	Total    int             `json:"total"`
+	Libyears float64         `json:"libyears"`
+	Groups   []OutdatedGroup `json:"groups"`
+}
+
+// NewOutdatedReport groups the dependencies behind their latest version by ecosystem and upgrade class.
+// Up to date dependencies and downgrades are left out, see GetPurlVersionsBySbom for the purl keyed versions.
+func NewOutdatedReport(versions client.PurlVersionStructMap) *OutdatedReport {
+	purls := make([]string, 0, len(versions))
+	for purl := range versions {
+		purls = append(purls, purl)
+	}
+	sort.Strings(purls)
+
+	report := &OutdatedReport{}
+	groups := make(map[string]*OutdatedGroup)
+	for _, purl := range purls {
+		purl_version := versions[purl]
+		if purl_version.CurrentVersion == nil || purl_version.LatestVersion == nil {
+			continue
+		}
+		if purl_version.IsVersionEquel || purl_version.Upgrade == versioning.UpgradeDowngrade {
+			continue
+		}
+
+		current := purl_version.CurrentVersion
+		name := current.Name
+		if current.Namespace != "" {
+			name = current.Namespace + "/" + current.Name
+		}
+		dependency := OutdatedDependency{
+			Purl:      purl,
+			Ecosystem: current.Type,
+			Name:      name,
+			Current:   current.Version,
+			Latest:    purl_version.LatestVersion.Version,
+			Upgrade:   purl_version.Upgrade,
+		}
+		if !purl_version.CurrentPublished.IsZero() && !purl_version.LatestPublished.IsZero() {
+			libyears := purl_version.LatestPublished.Sub(purl_version.CurrentPublished).Hours() / hoursPerYear
+			if libyears < 0 {
+				libyears = 0
+			}
+			dependency.Libyears = &libyears
+		}
+
+		key := dependency.Ecosystem + "/" + string(dependency.Upgrade)
+		group, ok := groups[key]
+		if !ok {
+			group = &OutdatedGroup{Ecosystem: dependency.Ecosystem, Upgrade: dependency.Upgrade}
+			groups[key] = group
+		}
+		group.Dependencies = append(group.Dependencies, dependency)
+		if dependency.Libyears != nil {
+			group.Libyears += *dependency.Libyears
+			report.Libyears += *dependency.Libyears
+		}
+		report.Total++
+	}
+
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		return upgradeOrder[a.Upgrade] < upgradeOrder[b.Upgrade]
+	})
+	return report
+}
+
+// Write renders the report in format.
+func (report *OutdatedReport) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatTable:
+		return report.writeTable(w)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case FormatCSV:
+		return report.writeCSV(w)
+	case FormatMarkdown:
+		return report.writeMarkdown(w)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+func formatLibyears(libyears *float64) string {
+	if libyears == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*libyears, 'f', 2, 64)
+}
+
+func (report *OutdatedReport) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ECOSYSTEM\tUPGRADE\tNAME\tCURRENT\tLATEST\tLIBYEARS")
+	for _, group := range report.Groups {
+		for _, dependency := range group.Dependencies {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", dependency.Ecosystem, dependency.Upgrade, dependency.Name,
+				dependency.Current, dependency.Latest, formatLibyears(dependency.Libyears))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d outdated, %.2f libyears\n", report.Total, report.Libyears)
+	return err
+}
+
+func (report *OutdatedReport) writeCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"ecosystem", "upgrade", "purl", "name", "current", "latest", "libyears"})
+	for _, group := range report.Groups {
+		for _, dependency := range group.Dependencies {
+			writer.Write([]string{dependency.Ecosystem, string(dependency.Upgrade), dependency.Purl, dependency.Name,
+				dependency.Current, dependency.Latest, formatLibyears(dependency.Libyears)})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// markdownEscape keeps pipes in names from breaking the table.
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+func (report *OutdatedReport) writeMarkdown(w io.Writer) error {
+	fmt.Fprintf(w, "# Outdated dependencies\n\n%d outdated, %.2f libyears\n", report.Total, report.Libyears)
+	for _, group := range report.Groups {
+		fmt.Fprintf(w, "\n## %s %s (%d)\n\n", group.Ecosystem, group.Upgrade, len(group.Dependencies))
+		fmt.Fprintln(w, "| Name | Current | Latest | Libyears |")
+		fmt.Fprintln(w, "| --- | --- | --- | --- |")
+		for _, dependency := range group.Dependencies {
+			fmt.Fprintf(w, "| %s | %s | %s | %s |\n", markdownEscape(dependency.Name), markdownEscape(dependency.Current),
+				markdownEscape(dependency.Latest), formatLibyears(dependency.Libyears))
+		}
+	}
+	return nil
+}
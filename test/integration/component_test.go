@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"deptrack/client"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestComponentDecode(t *testing.T) {
+	tests := []struct {
+		raw       string
+		purl_type string
+		purl_name string
+		purl      string
+	}{
+		{
+			raw:       "test-fixtures/component/component.json",
+			purl_type: "maven",
+			purl_name: "log4j-core",
+			purl:      "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?type=jar",
+		},
+		{
+			// A purl the parser rejects is kept as is
+			raw:  `{"uuid": "5a1ba4b8-6f3b-4d2e-9c5e-1f2f1ad9a0c1", "name": "vendored", "purl": "not a purl"}`,
+			purl: "not a purl",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.raw, func(t *testing.T) {
+			raw := []byte(test.raw)
+			if test.purl_type != "" {
+				var err error
+				raw, err = ioutil.ReadFile(test.raw)
+				assert.NilError(t, err, "Read fixture")
+			}
+
+			var component client.Component
+			assert.NilError(t, json.Unmarshal(raw, &component), "Decode component")
+			assert.Equal(t, component.Purl.Type, test.purl_type)
+			assert.Equal(t, component.Purl.Name, test.purl_name)
+			assert.Equal(t, component.PurlString(), test.purl)
+
+			encoded, err := json.Marshal(component)
+			assert.NilError(t, err, "Encode component")
+			var decoded client.Component
+			assert.NilError(t, json.Unmarshal(encoded, &decoded), "Decode encoded component")
+			assert.DeepEqual(t, decoded, component)
+			assert.Assert(t, strings.Contains(string(encoded), `"purl":"`+test.purl+`"`), string(encoded))
+		})
+	}
+}
+
+func TestComponentDecodeReuse(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous string
+	}{
+		{name: "parsed purl", previous: `{"name": "lodash", "purl": "pkg:npm/lodash@4.17.21"}`},
+		{name: "raw purl", previous: `{"name": "vendored", "purl": "not a purl"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var component client.Component
+			assert.NilError(t, json.Unmarshal([]byte(test.previous), &component), "Decode previous component")
+			assert.Assert(t, component.PurlString() != "")
+
+			assert.NilError(t, json.Unmarshal([]byte(`{"name": "internal"}`), &component), "Decode component")
+			assert.Equal(t, component.Name, "internal")
+			assert.Equal(t, component.PurlString(), "")
+		})
+	}
+}
+
+func TestComponentFields(t *testing.T) {
+	raw, err := ioutil.ReadFile("test-fixtures/component/component.json")
+	assert.NilError(t, err, "Read fixture")
+
+	var component client.Component
+	assert.NilError(t, json.Unmarshal(raw, &component), "Decode component")
+	assert.Equal(t, component.Classifier, client.ClassifierLibrary)
+	assert.Equal(t, component.Scope, client.ComponentScopeRequired)
+	assert.Equal(t, component.Sha3_256[:4], "b5a9")
+	assert.Equal(t, component.Blake2b256[:4], "c1d2")
+	assert.Equal(t, len(component.ExternalReferences), 2)
+	assert.Equal(t, component.Parent.UUID, "0c3c6e0a-1a7e-4a5c-8f0e-2b9d1e5a7c31")
+	assert.Equal(t, component.Project.Name, "backend")
+	assert.Equal(t, component.ResolvedLicense.LicenseId, "Apache-2.0")
+}
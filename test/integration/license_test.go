@@ -0,0 +1,68 @@
+package integration
+
+import (
+	"deptrack/client"
+	"os"
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"gotest.tools/assert"
+)
+
+func TestLicenseCompliance(t *testing.T) {
+	sbom, err := os.Open("test-fixtures/license/sbom.json")
+	assert.NilError(t, err, "Open sbom")
+	defer sbom.Close()
+
+	var bom cdx.BOM
+	assert.NilError(t, cdx.NewBOMDecoder(sbom, cdx.BOMFileFormatJSON).Decode(&bom), "Decode sbom")
+
+	groups := client.LicenseGroupList{
+		{Name: "Permissive", Licenses: client.LicenseList{
+			{LicenseId: "MIT", Name: "MIT License"},
+			{LicenseId: "Apache-2.0", Name: "Apache License (Version 2.0)"},
+		}},
+		{Name: "Copyleft", Licenses: client.LicenseList{
+			{LicenseId: "GPL-2.0-only"},
+			{LicenseId: "GPL-3.0-or-later"},
+		}},
+	}
+	report, err := client.EvaluateLicenseCompliance(&bom, groups, client.ComplianceConfig{Allowed: []string{"Permissive"}, Denied: []string{"Copyleft"}})
+	assert.NilError(t, err, "Evaluate compliance")
+
+	tests := []struct {
+		name     string
+		licenses string
+		status   client.LicenseStatus
+		err      string
+	}{
+		{name: "lodash", licenses: "MIT", status: client.LicenseAllowed},
+		{name: "readline", licenses: "GPL-3.0-or-later", status: client.LicenseDenied},
+		{name: "dual", licenses: "(GPL-2.0-only OR MIT)", status: client.LicenseAllowed},
+		{name: "mixed", licenses: "Apache-2.0 AND GPL-2.0-only WITH Classpath-exception-2.0", status: client.LicenseDenied},
+		{name: "org.example/named", licenses: "Apache License (Version 2.0)", status: client.LicenseAllowed},
+		{name: "custom", licenses: "LicenseRef-Proprietary", status: client.LicenseUnknown},
+		{name: "bare", status: client.LicenseMissing},
+		// Invalid expressions are kept as is and evaluated as unknown
+		{name: "broken", licenses: "MIT AND (GPL-2.0-only", status: client.LicenseUnknown, err: "missing closing parenthesis"},
+		{name: "broken-denied", licenses: "GPL-2.0-only AND (MIT OR)", status: client.LicenseDenied, err: "license expected"},
+	}
+	assert.Equal(t, len(report.Components), len(tests))
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			component := report.Components[i]
+			assert.Equal(t, component.Name, test.name)
+			assert.Equal(t, component.Licenses, test.licenses)
+			assert.Equal(t, component.Status, test.status)
+			assert.Assert(t, strings.Contains(component.Error, test.err), component.Error)
+			assert.Equal(t, component.Error == "", test.err == "")
+		})
+	}
+	assert.Equal(t, report.Counts[client.LicenseDenied], 3)
+	assert.Equal(t, report.Counts[client.LicenseUnknown], 2)
+	assert.Assert(t, !report.Compliant())
+
+	_, err = client.EvaluateLicenseCompliance(&bom, groups, client.ComplianceConfig{Allowed: []string{"Weak copyleft"}})
+	assert.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"context"
+	"deptrack/client"
+	"deptrack/osv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestOSVConvert(t *testing.T) {
+	advisories, err := osv.LoadDir("test-fixtures/osv")
+	assert.NilError(t, err, "Load advisories")
+	assert.Equal(t, len(advisories), 3)
+	assert.Equal(t, advisories[0].Withdrawn, "2023-11-20T00:00:00Z")
+
+	vulnraibility, err := advisories[1].ToVulnerability()
+	assert.NilError(t, err, "Convert advisory")
+	assert.Equal(t, vulnraibility.VulnId, "INT-2024-0001")
+	assert.Equal(t, vulnraibility.Source, client.SourceInternal)
+	assert.Equal(t, vulnraibility.Severity, "HIGH")
+	assert.Equal(t, vulnraibility.CvssV3Vector, "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:U/C:N/I:H/A:N")
+	assert.DeepEqual(t, vulnraibility.AllCwes(), []client.Cwe{{CweId: 22}})
+	assert.Equal(t, len(vulnraibility.ReferenceUrls()), 2)
+	assert.Equal(t, vulnraibility.PublishedTime().UTC().Format("2006-01-02T15:04:05Z"), "2024-02-28T08:30:00Z")
+
+	assert.DeepEqual(t, vulnraibility.AffectedComponents, []client.AffectedComponent{
+		{IdentityType: client.IdentityTypePurl, Identity: "pkg:npm/%40scribe/archive", VersionType: client.VersionTypeRange, VersionEndExcluding: "1.4.2"},
+		{IdentityType: client.IdentityTypePurl, Identity: "pkg:npm/%40scribe/archive", VersionType: client.VersionTypeRange, VersionStartIncluding: "2.0.0", VersionEndIncluding: "2.1.0"},
+		{IdentityType: client.IdentityTypePurl, Identity: "pkg:maven/io.scribe/archive-core", VersionType: client.VersionTypeExact, Version: "3.0.0"},
+		{IdentityType: client.IdentityTypePurl, Identity: "pkg:maven/io.scribe/archive-core", VersionType: client.VersionTypeExact, Version: "3.0.1"},
+	})
+}
+
+func TestOSVConvertEcosystems(t *testing.T) {
+	advisory, err := osv.Load("test-fixtures/osv/INT-2024-0002.json")
+	assert.NilError(t, err, "Load advisory")
+
+	vulnraibility, err := advisory.ToVulnerability()
+	assert.NilError(t, err, "Convert advisory")
+	assert.Equal(t, vulnraibility.Severity, "MEDIUM")
+	// The Bioconductor package has no purl type and is skipped
+	assert.DeepEqual(t, vulnraibility.AffectedComponents, []client.AffectedComponent{
+		{IdentityType: client.IdentityTypePurl, Identity: "pkg:deb/debian/zlib", VersionType: client.VersionTypeExact, Version: "1:1.2.11.dfsg-2"},
+		{IdentityType: client.IdentityTypePurl, Identity: "pkg:apk/alpine/zlib", VersionType: client.VersionTypeExact, Version: "1.2.13-r1"},
+	})
+}
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity string
+	}{
+		{name: "critical", severity: "CRITICAL"},
+		{name: "HIGH", severity: "HIGH"},
+		{name: "moderate", severity: "MEDIUM"},
+		{name: "Medium", severity: "MEDIUM"},
+		{name: "low", severity: "LOW"},
+		{name: "info", severity: "INFO"},
+		{name: "important", severity: "UNASSIGNED"},
+		{name: "", severity: "UNASSIGNED"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, osv.ParseSeverity(test.name), test.severity)
+		})
+	}
+}
+
+func TestOSVImport(t *testing.T) {
+	advisories, err := osv.LoadDir("test-fixtures/osv")
+	assert.NilError(t, err, "Load advisories")
+
+	// Server updated times of the advisory modified times
+	modified_0001 := int64(1709373600000)
+	modified_0002 := int64(1712750400000)
+
+	tests := []struct {
+		name     string
+		existing map[string]int64
+		changes  []osv.ImportChange
+		requests []string
+	}{
+		{
+			name: "create",
+			changes: []osv.ImportChange{
+				{Action: osv.ActionCreate, VulnId: "INT-2024-0001"},
+				{Action: osv.ActionCreate, VulnId: "INT-2024-0002"},
+			},
+			requests: []string{"PUT INT-2024-0001", "PUT INT-2024-0002"},
+		},
+		{
+			name:     "unchanged",
+			existing: map[string]int64{"INT-2023-0007": 1, "INT-2024-0001": modified_0001, "INT-2024-0002": modified_0002},
+			changes:  []osv.ImportChange{{Action: osv.ActionDelete, VulnId: "INT-2023-0007"}},
+			requests: []string{"DELETE uuid-INT-2023-0007"},
+		},
+		{
+			name:     "modified",
+			existing: map[string]int64{"INT-2024-0001": modified_0001 - 1, "INT-2024-0002": modified_0002},
+			changes:  []osv.ImportChange{{Action: osv.ActionUpdate, VulnId: "INT-2024-0001"}},
+			requests: []string{"POST INT-2024-0001"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests []string
+			c := NewFakeDepClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					vuln_id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+					updated, ok := test.existing[vuln_id]
+					if !ok {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					json.NewEncoder(w).Encode(client.Vulnraibility{UUID: "uuid-" + vuln_id, VulnId: vuln_id, Source: client.SourceInternal, Updated: updated})
+					return
+				}
+
+				if r.Method == http.MethodDelete {
+					requests = append(requests, r.Method+" "+r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+					return
+				}
+				var vulnraibility client.Vulnraibility
+				json.NewDecoder(r.Body).Decode(&vulnraibility)
+				requests = append(requests, r.Method+" "+vulnraibility.VulnId)
+				json.NewEncoder(w).Encode(vulnraibility)
+			})
+
+			changes, err := osv.ImportContext(context.Background(), c, advisories, osv.ImportOptions{})
+			assert.NilError(t, err, "Import advisories")
+			assert.DeepEqual(t, changes, test.changes)
+			assert.DeepEqual(t, requests, test.requests)
+		})
+	}
+}
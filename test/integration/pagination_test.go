@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"context"
+	"deptrack/client"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestCollectAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    int
+		total    bool
+		requests int
+	}{
+		{name: "total count", items: 25, total: true, requests: 3},
+		{name: "short last page", items: 25, requests: 3},
+		{name: "full last page", items: 20, requests: 3},
+		{name: "empty", total: true, requests: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var queries []url.Values
+			c := NewFakeDepClient(t, func(w http.ResponseWriter, r *http.Request) {
+				query := r.URL.Query()
+				queries = append(queries, query)
+				offset, _ := strconv.Atoi(query.Get("offset"))
+				page := []client.Component{}
+				for i := offset; i < test.items && i < offset+10; i++ {
+					page = append(page, client.Component{Name: "requests", Version: strconv.Itoa(i)})
+				}
+				if test.total {
+					w.Header().Set(client.TotalCountHeader, strconv.Itoa(test.items))
+				}
+				json.NewEncoder(w).Encode(page)
+			})
+
+			// The params pagination is replaced by the paginator
+			params := client.GetComponentsIdentityParams{Name: "requests", PaginationParams: client.PaginationParams{Offset: 5, Limit: 1}}
+			var components client.ComponentList
+			err := c.CollectAllContext(context.Background(), client.ApiComponentIdentity, params, 10, &components)
+			assert.NilError(t, err, "Collect all")
+
+			assert.Equal(t, len(queries), test.requests, queries)
+			for i, query := range queries {
+				assert.Equal(t, query.Get("name"), "requests")
+				assert.Equal(t, query.Get("offset"), strconv.Itoa(i*10))
+				assert.Equal(t, query.Get("limit"), "10")
+			}
+			assert.Equal(t, len(components), test.items)
+			for i, component := range components {
+				assert.Equal(t, component.Version, strconv.Itoa(i))
+			}
+		})
+	}
+}
+
+func TestPaginatorTotal(t *testing.T) {
+	var query url.Values
+	c := NewFakeDepClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set(client.TotalCountHeader, "2")
+		w.Write([]byte(`[{"vulnId":"CVE-2021-1"},{"vulnId":"CVE-2021-2"}]`))
+	})
+
+	paginator := c.NewPaginator(client.ApiVulnrabilityComponent+"/uuid", client.GetVulnerabilityByUUIDParams{Suppressed: true}, 0)
+	assert.Equal(t, paginator.Total(), -1)
+
+	var page client.VulnraibilityList
+	ok, err := paginator.NextPage(context.Background(), &page)
+	assert.NilError(t, err, "First page")
+	assert.Assert(t, ok)
+	assert.Equal(t, paginator.Total(), 2)
+	assert.Equal(t, query.Get("suppressed"), "true")
+	assert.Equal(t, len(page), 2)
+
+	ok, err = paginator.NextPage(context.Background(), &page)
+	assert.NilError(t, err, "Past the last page")
+	assert.Assert(t, !ok)
+}
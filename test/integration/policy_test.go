@@ -0,0 +1,54 @@
+package integration
+
+import (
+	"deptrack/client"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestEvaluateViolations(t *testing.T) {
+	raw, err := ioutil.ReadFile("test-fixtures/policy/violations.json")
+	assert.NilError(t, err, "Read fixture")
+	var violations client.PolicyViolationList
+	assert.NilError(t, json.Unmarshal(raw, &violations), "Decode violations")
+
+	tests := []struct {
+		name       string
+		cfg        client.GateConfig
+		components []string
+	}{
+		{
+			name:       "suppressed and approved skipped",
+			components: []string{"pkg:pypi/chardet@4.0.0"},
+		},
+		{
+			name:       "include suppressed",
+			cfg:        client.GateConfig{IncludeSuppressed: true},
+			components: []string{"pkg:pypi/chardet@4.0.0", "urllib3@1.26.4", "pkg:pypi/idna@2.10"},
+		},
+		{
+			name:       "fail on warn",
+			cfg:        client.GateConfig{FailOn: []string{client.ViolationStateWarn}},
+			components: []string{"pkg:pypi/requests@2.25.1"},
+		},
+		{
+			name: "security only",
+			cfg:  client.GateConfig{Types: []string{client.ViolationTypeSecurity}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := client.EvaluateViolations(violations, test.cfg)
+			var components []string
+			for _, reason := range result.Reasons {
+				components = append(components, reason.Component)
+			}
+			assert.DeepEqual(t, components, test.components)
+			assert.Equal(t, result.Passed, len(test.components) == 0)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"context"
+	"deptrack/client"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"gotest.tools/assert"
+)
+
+func poolBom(count int) *cdx.BOM {
+	components := make([]cdx.Component, count)
+	for i := range components {
+		components[i] = cdx.Component{Type: "library", Name: fmt.Sprint(i), PackageURL: fmt.Sprintf("pkg:pypi/c%d@1.0.0", i)}
+	}
+	return &cdx.BOM{Components: &components}
+}
+
+func TestWalkSbomComponents(t *testing.T) {
+	failure := errors.New("lookup failed")
+	tests := []struct {
+		name      string
+		fail_fast bool
+		failing   map[int]bool
+		failed    []int
+	}{
+		{name: "collect", failing: map[int]bool{5: true, 3: true}, failed: []int{3, 5}},
+		{name: "fail fast", fail_fast: true, failing: map[int]bool{3: true}},
+		{name: "no errors"},
+	}
+
+	c, err := client.NewDepTrackClient("api-key", ApiServerPath)
+	assert.NilError(t, err, "Failed to create client")
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := client.WalkOptions{Workers: 4, FailFast: test.fail_fast}
+			err := c.WalkSbomComponents(context.Background(), poolBom(8), opts, func(ctx context.Context, index int, component cdx.Component) error {
+				if test.failing[index] {
+					return failure
+				}
+				if !test.fail_fast {
+					return nil
+				}
+				// Running lookups only end once the pool is cancelled
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(time.Second):
+					return nil
+				}
+			})
+
+			switch {
+			case test.fail_fast:
+				assert.Equal(t, err, failure)
+			case len(test.failed) != 0:
+				var walk_err *client.WalkError
+				assert.Assert(t, errors.As(err, &walk_err), err)
+				var failed []int
+				for _, component_err := range walk_err.Errors {
+					failed = append(failed, component_err.Index)
+					assert.Equal(t, component_err.Err, failure)
+				}
+				assert.DeepEqual(t, failed, test.failed)
+			default:
+				assert.NilError(t, err, "Walk")
+			}
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	tests := []struct {
+		rate float64
+		min  time.Duration
+		max  time.Duration
+	}{
+		{rate: 100, min: 20 * time.Millisecond, max: time.Second},
+		{rate: 0, max: 10 * time.Millisecond},
+		{rate: -1, max: 10 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.rate), func(t *testing.T) {
+			limiter := client.NewRateLimiter(test.rate)
+			start := time.Now()
+			for i := 0; i < 3; i++ {
+				assert.NilError(t, limiter.Wait(context.Background()), "Wait")
+			}
+			elapsed := time.Since(start)
+			assert.Assert(t, elapsed >= test.min && elapsed <= test.max, elapsed)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"deptrack/client"
+	"deptrack/reconcile"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestReconcileDiff(t *testing.T) {
+	spec, err := reconcile.LoadSpec("test-fixtures/reconcile/spec.yaml")
+	assert.NilError(t, err, "Load spec")
+
+	matching := &reconcile.State{
+		Teams: client.TeamList{
+			{UUID: "1", Name: "Scribe_backend", ApiKeys: []client.ApiKey{{Key: "key"}}, Permissions: client.DepTrackPermissionsList{{Name: "BOM_UPLOAD"}, {Name: "VIEW_PORTFOLIO"}}},
+			{UUID: "2", Name: "Security", Permissions: client.DepTrackPermissionsList{{Name: "VULNERABILITY_ANALYSIS"}}},
+		},
+		Policies: client.PolicyList{
+			{UUID: "3", Name: "Forbidden licenses", Operator: "ANY", ViolationState: "FAIL",
+				PolicyConditions: []client.PolicyCondition{{UUID: "4", Subject: "LICENSE", Operator: "IS", Value: "GPL-3.0-only"}},
+				Tags:             client.NewTags("production")},
+		},
+		Publishers: client.NotificationPublisherList{{UUID: "5", Name: "Outbound Webhook"}, {UUID: "8", Name: "Email"}},
+		NotificationRules: client.NotificationRuleList{
+			{UUID: "6", Name: "Scribe webhook", Enabled: true, Scope: "PORTFOLIO", NotificationLevel: "INFORMATIONAL",
+				NotifyOn: []string{"NEW_VULNERABILITY", "BOM_PROCESSED"}, PublisherConfig: `{"destination":"http://scribe:8080/deptrack"}`,
+				Publisher: &client.NotificationPublisher{UUID: "5", Name: "Outbound Webhook"}},
+		},
+		Repositories:     client.RepositoryList{{UUID: "7", Type: "PYPI", Identifier: "pypi.org", Url: "https://pypi.org/", ResolutionOrder: 1, Enabled: true}},
+		ConfigProperties: client.ConfigPropertyList{{GroupName: "general", PropertyName: "base.url", PropertyValue: "http://localhost:8080"}},
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(state *reconcile.State)
+		changes []string
+	}{
+		{
+			name:   "no drift",
+			mutate: func(state *reconcile.State) {},
+		},
+		{
+			name: "missing team",
+			mutate: func(state *reconcile.State) {
+				state.Teams = state.Teams[:1]
+			},
+			changes: []string{"+ team Security: permissions VULNERABILITY_ANALYSIS"},
+		},
+		{
+			name: "team permissions and key",
+			mutate: func(state *reconcile.State) {
+				state.Teams[0].ApiKeys = nil
+				state.Teams[0].Permissions = client.DepTrackPermissionsList{{Name: "BOM_UPLOAD"}, {Name: "ACCESS_MANAGEMENT"}}
+			},
+			changes: []string{
+				"~ team Scribe_backend: add permission VIEW_PORTFOLIO",
+				"~ team Scribe_backend: remove permission ACCESS_MANAGEMENT",
+				"~ team Scribe_backend: generate api key",
+			},
+		},
+		{
+			name: "policy condition and tag",
+			mutate: func(state *reconcile.State) {
+				state.Policies[0].PolicyConditions[0].Value = "AGPL-3.0-only"
+				state.Policies[0].Tags = client.NewTags("staging")
+			},
+			changes: []string{
+				"~ policy Forbidden licenses: add condition LICENSE IS GPL-3.0-only",
+				"- policy Forbidden licenses: remove condition LICENSE IS AGPL-3.0-only",
+				"~ policy Forbidden licenses: add tag production",
+				"- policy Forbidden licenses: remove tag staging",
+			},
+		},
+		{
+			name: "disabled rule, repository and config",
+			mutate: func(state *reconcile.State) {
+				state.NotificationRules[0].Enabled = false
+				state.Repositories[0].ResolutionOrder = 2
+				state.ConfigProperties[0].PropertyValue = ""
+			},
+			changes: []string{
+				"~ notification rule Scribe webhook: enabled false -> true",
+				"~ repository PYPI/pypi.org: resolutionOrder 2 -> 1",
+				`~ config property general/base.url: "" -> "http://localhost:8080"`,
+			},
+		},
+		{
+			name: "rule publisher",
+			mutate: func(state *reconcile.State) {
+				state.NotificationRules[0].Publisher = &client.NotificationPublisher{UUID: "8", Name: "Email"}
+			},
+			changes: []string{
+				"- notification rule Scribe webhook: replace, publisher Email -> Outbound Webhook",
+				"+ notification rule Scribe webhook: PORTFOLIO INFORMATIONAL via Outbound Webhook on BOM_PROCESSED, NEW_VULNERABILITY",
+			},
+		},
+		{
+			name: "rule scope",
+			mutate: func(state *reconcile.State) {
+				state.NotificationRules[0].Scope = "SYSTEM"
+			},
+			changes: []string{
+				"- notification rule Scribe webhook: replace, scope SYSTEM -> PORTFOLIO",
+				"+ notification rule Scribe webhook: PORTFOLIO INFORMATIONAL via Outbound Webhook on BOM_PROCESSED, NEW_VULNERABILITY",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, err := copyState(matching)
+			assert.NilError(t, err, "Copy state")
+			test.mutate(state)
+
+			plan, err := reconcile.Diff(nil, state, spec)
+			assert.NilError(t, err, "Diff")
+
+			var changes []string
+			for _, change := range plan.Changes {
+				changes = append(changes, change.String())
+			}
+			assert.DeepEqual(t, changes, test.changes)
+			assert.Equal(t, plan.HasChanges(), len(test.changes) != 0)
+
+			var out bytes.Buffer
+			plan.Print(&out)
+			assert.Assert(t, out.Len() != 0, "Empty plan output")
+		})
+	}
+}
+
+func copyState(state *reconcile.State) (*reconcile.State, error) {
+	v, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	var copied reconcile.State
+	err = json.Unmarshal(v, &copied)
+	return &copied, err
+}
+
+func TestReconcileApplyApiKeys(t *testing.T) {
+	spec := &reconcile.Spec{
+		Teams: []client.TeamConfig{
+			{Name: "Scribe_backend", Permissions: []string{"BOM_UPLOAD"}, ApiKey: true},
+			{Name: "Security", Permissions: []string{"VULNERABILITY_ANALYSIS"}, ApiKey: true},
+			{Name: "Audit", Permissions: []string{"VIEW_PORTFOLIO"}},
+		},
+	}
+	state := &reconcile.State{
+		Teams: client.TeamList{{UUID: "1", Name: "Scribe_backend", Permissions: client.DepTrackPermissionsList{{Name: "BOM_UPLOAD"}}}},
+	}
+
+	var requests []string
+	c := NewFakeDepClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/team":
+			var team client.Team
+			json.NewDecoder(r.Body).Decode(&team)
+			json.NewEncoder(w).Encode(client.Team{UUID: "uuid-" + team.Name, Name: team.Name})
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/key"):
+			team_uuid := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/team/"), "/key")
+			json.NewEncoder(w).Encode(client.ApiKey{Key: "key-" + team_uuid})
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/api/v1/permission/"):
+			team_uuid := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			json.NewEncoder(w).Encode(client.Team{UUID: team_uuid, Name: strings.TrimPrefix(team_uuid, "uuid-")})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	plan, err := reconcile.Diff(c, state, spec)
+	assert.NilError(t, err, "Diff")
+	applied, err := plan.Apply(context.Background())
+	assert.NilError(t, err, "Apply")
+	assert.Equal(t, applied, len(plan.Changes))
+
+	assert.DeepEqual(t, plan.GeneratedKeys, map[string]client.ApiKey{
+		"Scribe_backend": {Key: "key-1"},
+		"Security":       {Key: "key-uuid-Security"},
+	})
+	assert.Equal(t, len(requests), 6, requests)
+}
@@ -0,0 +1,195 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"deptrack/client"
+	"deptrack/report"
+	"deptrack/versioning"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	packageurl "github.com/package-url/packageurl-go"
+	"gotest.tools/assert"
+)
+
+func purlVersion(t *testing.T, current string, latest_version string, current_published time.Time, latest_published time.Time) client.PurlVersionStruct {
+	current_purl, err := packageurl.FromString(current)
+	assert.NilError(t, err, "Parse purl")
+	latest_purl := current_purl
+	latest_purl.Version = latest_version
+
+	purl_version := client.NewPurlVersionStruct(&current_purl, &latest_purl)
+	purl_version.CurrentPublished = current_published
+	purl_version.LatestPublished = latest_published
+	return purl_version
+}
+
+// tableRows splits the table writer output in rows of whitespace separated cells, empty cells and lines are dropped.
+func tableRows(out string) [][]string {
+	var rows [][]string
+	for _, line := range strings.Split(out, "\n") {
+		if cells := strings.Fields(line); len(cells) != 0 {
+			rows = append(rows, cells)
+		}
+	}
+	return rows
+}
+
+func TestOutdatedReport(t *testing.T) {
+	published := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	versions := client.PurlVersionStructMap{
+		"pkg:npm/lodash@4.17.15": purlVersion(t, "pkg:npm/lodash@4.17.15", "4.17.21", published.AddDate(-2, 0, 0), published),
+		// Only the latest release date is known
+		"pkg:npm/express@3.21.2": purlVersion(t, "pkg:npm/express@3.21.2", "4.17.2", time.Time{}, published),
+		"pkg:pypi/lodash@1.0.0":  purlVersion(t, "pkg:pypi/lodash@1.0.0", "1.0.0", time.Time{}, time.Time{}),
+		"pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.9.10": purlVersion(t, "pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.9.10", "2.13.1", time.Time{}, time.Time{}),
+	}
+	outdated := report.NewOutdatedReport(versions)
+
+	assert.Equal(t, outdated.Total, 3)
+	assert.Equal(t, len(outdated.Groups), 3)
+	assert.Equal(t, outdated.Groups[0].Ecosystem, "maven")
+	assert.Equal(t, outdated.Groups[1].Upgrade, versioning.UpgradeMajor)
+	assert.Equal(t, outdated.Groups[2].Dependencies[0].Name, "lodash")
+	assert.Assert(t, outdated.Groups[1].Dependencies[0].Libyears == nil)
+	assert.Assert(t, outdated.Libyears > 1.99 && outdated.Libyears < 2.01)
+
+	tests := []struct {
+		format   report.Format
+		contains string
+	}{
+		{format: report.FormatJSON, contains: `"purl": "pkg:npm/lodash@4.17.15"`},
+		{format: report.FormatCSV, contains: "npm,patch,pkg:npm/lodash@4.17.15,lodash,4.17.15,4.17.21,2.00"},
+		{format: report.FormatMarkdown, contains: "| com.fasterxml.jackson.core/jackson-databind | 2.9.10 | 2.13.1 |  |"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			var out bytes.Buffer
+			assert.NilError(t, outdated.Write(&out, test.format), "Write report")
+			assert.Assert(t, strings.Contains(out.String(), test.contains), out.String())
+		})
+	}
+
+	t.Run(string(report.FormatTable), func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NilError(t, outdated.Write(&out, report.FormatTable), "Write report")
+		assert.DeepEqual(t, tableRows(out.String()), [][]string{
+			{"ECOSYSTEM", "UPGRADE", "NAME", "CURRENT", "LATEST", "LIBYEARS"},
+			{"maven", "minor", "com.fasterxml.jackson.core/jackson-databind", "2.9.10", "2.13.1"},
+			{"npm", "major", "express", "3.21.2", "4.17.2"},
+			{"npm", "patch", "lodash", "4.17.15", "4.17.21", "2.00"},
+			{"3", "outdated,", "2.00", "libyears"},
+		})
+	})
+}
+
+func TestPurlVersionsBySbom(t *testing.T) {
+	current_published := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	latest_published := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	components := []cdx.Component{
+		{Type: "library", Name: "lodash", PackageURL: "pkg:npm/lodash@4.17.15"},
+		{Type: "library", Name: "lodash", PackageURL: "pkg:pypi/lodash@1.0.0"},
+	}
+	bom := &cdx.BOM{Components: &components}
+
+	tests := []struct {
+		name          string
+		version       string
+		release_dates bool
+	}{
+		{name: "integrity metadata", version: "4.11.0", release_dates: true},
+		{name: "old server", version: "4.10.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewFakeDepClient(t, func(w http.ResponseWriter, r *http.Request) {
+				purl, _ := packageurl.FromString(r.URL.Query().Get("purl"))
+				switch r.URL.Path {
+				case "/api/version":
+					json.NewEncoder(w).Encode(client.ServerInfo{Version: test.version})
+				case "/api/v1/repository/latest":
+					json.NewEncoder(w).Encode(client.VersionResponse{RepositoryType: purl.Type, Name: purl.Name, LatestVersion: "4.17.21",
+						Published: latest_published.UnixNano() / int64(time.Millisecond)})
+				case "/api/v1/component/integritymetadata":
+					if purl.Type != packageurl.TypeNPM {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					json.NewEncoder(w).Encode(client.IntegrityMetadata{PublishedAt: current_published.UnixNano() / int64(time.Millisecond)})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			versions, err := c.GetPurlVersionsBySbomContext(context.Background(), bom, client.DefaultWalkOptions)
+			assert.NilError(t, err, "Purl versions")
+			assert.Equal(t, len(versions), 2)
+			npm := versions["pkg:npm/lodash@4.17.15"]
+			assert.Assert(t, npm.LatestPublished.Equal(latest_published))
+			assert.Equal(t, npm.CurrentPublished.Equal(current_published), test.release_dates)
+			assert.Assert(t, versions["pkg:pypi/lodash@1.0.0"].CurrentPublished.IsZero())
+
+			// The name keyed lookup keeps one of the same named components
+			by_name, err := c.GetLatestVersionBySbomContext(context.Background(), bom)
+			assert.NilError(t, err, "Latest versions")
+			assert.Equal(t, len(by_name), 1)
+		})
+	}
+}
+
+func TestBlastRadiusReport(t *testing.T) {
+	blast_radius := &client.BlastRadius{
+		Vulnerability: &client.Vulnraibility{Source: client.SourceNVD, VulnId: "CVE-2021-44228", Severity: "CRITICAL"},
+		Projects:      2,
+		Entries: []client.BlastRadiusEntry{
+			{Project: "p1", ProjectName: "shop", ProjectVersion: "1.0", Component: "c1",
+				Purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", State: client.AnalysisStateExploitable},
+			{Project: "p2", ProjectName: "billing|api", Component: "c2",
+				Purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.13.0", State: client.AnalysisStateNotAffected, Suppressed: true},
+		},
+	}
+
+	tests := []struct {
+		format   report.Format
+		contains []string
+	}{
+		{format: report.FormatJSON, contains: []string{`"vulnId": "CVE-2021-44228"`, `"projectName": "billing|api"`, `"suppressed": true`}},
+		{format: report.FormatCSV, contains: []string{
+			"project,project_uuid,version,component_uuid,purl,analysis,suppressed",
+			"billing|api,p2,,c2,pkg:maven/org.apache.logging.log4j/log4j-core@2.13.0,NOT_AFFECTED,true",
+		}},
+		{format: report.FormatMarkdown, contains: []string{
+			"# Blast radius",
+			"| billing\\|api |  | pkg:maven/org.apache.logging.log4j/log4j-core@2.13.0 | NOT_AFFECTED | true |",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			var out bytes.Buffer
+			assert.NilError(t, report.WriteBlastRadius(&out, blast_radius, test.format), "Write blast radius")
+			for _, contains := range test.contains {
+				assert.Assert(t, strings.Contains(out.String(), contains), out.String())
+			}
+		})
+	}
+
+	t.Run(string(report.FormatTable), func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NilError(t, report.WriteBlastRadius(&out, blast_radius, report.FormatTable), "Write blast radius")
+		assert.DeepEqual(t, tableRows(out.String()), [][]string{
+			{"NVD", "CVE-2021-44228", "CRITICAL,", "2", "projects,", "2", "components"},
+			{"PROJECT", "VERSION", "PURL", "ANALYSIS", "SUPPRESSED"},
+			{"shop", "1.0", "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", "EXPLOITABLE", "false"},
+			{"billing|api", "pkg:maven/org.apache.logging.log4j/log4j-core@2.13.0", "NOT_AFFECTED", "true"},
+		})
+	})
+
+	err := report.WriteBlastRadius(&bytes.Buffer{}, blast_radius, report.Format("xml"))
+	assert.ErrorContains(t, err, "unknown report format")
+}
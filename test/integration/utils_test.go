@@ -2,6 +2,8 @@ package integration
 
 import (
 	"deptrack/client"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
@@ -24,6 +26,15 @@ func GetLocalDepClient(t *testing.T) *client.DepTrackClient {
 	return c
 }
 
+// NewFakeDepClient returns a client of an in process server answering with handler.
+func NewFakeDepClient(t *testing.T, handler http.HandlerFunc) *client.DepTrackClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := client.NewDepTrackClient("api-key", server.URL+"/api/v1")
+	assert.NilError(t, err, "Failed to create client")
+	return c
+}
+
 func GetCycloneDxManager(t *testing.T) *cdx_manager.CycloneDxManager {
 	cyclonedx_manager, err := cdx_manager.NewCycloneDxManager(cdx_manager.JSON_FORMAT)
 	assert.NilError(t, err, "Cyclonedx manager create")
@@ -0,0 +1,64 @@
+package integration
+
+import (
+	"context"
+	"deptrack/client"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestServerCapabilities(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		token_path string
+		vex        bool
+	}{
+		{name: "event token", version: "4.11.3", token_path: "/api/v1/event/token/token", vex: true},
+		{name: "bom token", version: "4.10.0", token_path: "/api/v1/bom/token/token", vex: true},
+		{name: "no vex", version: "4.4.2-SNAPSHOT", token_path: "/api/v1/bom/token/token"},
+		{name: "no version endpoint", token_path: "/api/v1/bom/token/token", vex: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var version_requests int32
+			var token_paths []string
+			c := NewFakeDepClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/api/version":
+					atomic.AddInt32(&version_requests, 1)
+					if test.version == "" {
+						http.NotFound(w, r)
+						return
+					}
+					w.Write([]byte(`{"application":"Dependency-Track","version":"` + test.version + `"}`))
+				default:
+					token_paths = append(token_paths, r.URL.Path)
+					w.Write([]byte(`{"processing":false}`))
+				}
+			})
+
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				state, err := c.GetBomProcessingStateContext(ctx, "token")
+				assert.NilError(t, err, "Processing state")
+				assert.Equal(t, state, client.BomStateFinished)
+			}
+			assert.DeepEqual(t, token_paths, []string{test.token_path, test.token_path, test.token_path})
+
+			assert.Equal(t, c.SupportsContext(ctx, client.CapabilityVex), test.vex)
+			if !test.vex {
+				_, err := c.ExportVEXContext(ctx, "project")
+				assert.Assert(t, errors.Is(err, client.ErrUnsupportedByServer), err)
+			}
+
+			// A failed version detection is cached as well
+			assert.Equal(t, atomic.LoadInt32(&version_requests), int32(1))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"deptrack/versioning"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		purl_type string
+		a         string
+		b         string
+		cmp       int
+	}{
+		{purl_type: "npm", a: "1.2.3", b: "1.10.0", cmp: -1},
+		{purl_type: "npm", a: "1.0.0-alpha", b: "1.0.0-alpha.1", cmp: -1},
+		{purl_type: "npm", a: "1.0.0-alpha.beta", b: "1.0.0-beta", cmp: -1},
+		{purl_type: "npm", a: "1.0.0-rc.1", b: "1.0.0", cmp: -1},
+		{purl_type: "npm", a: "1.0.0+build.1", b: "1.0.0", cmp: 0},
+		{purl_type: "golang", a: "v0.4.0", b: "v0.10.0", cmp: -1},
+		{purl_type: "golang", a: "v3.0.0+incompatible", b: "v3.0.0", cmp: 0},
+		{purl_type: "cargo", a: "1.0", b: "1.0.0", cmp: 0},
+		{purl_type: "pypi", a: "1.0.dev0", b: "1.0a1", cmp: -1},
+		{purl_type: "pypi", a: "1.0a1", b: "1.0b1", cmp: -1},
+		{purl_type: "pypi", a: "1.0rc1", b: "1.0", cmp: -1},
+		{purl_type: "pypi", a: "1.0", b: "1.0.post1", cmp: -1},
+		{purl_type: "pypi", a: "1.0", b: "1.0.0", cmp: 0},
+		{purl_type: "pypi", a: "1.0-alpha-2", b: "1.0a2", cmp: 0},
+		{purl_type: "pypi", a: "1.0+abc", b: "1.0+5", cmp: -1},
+		{purl_type: "pypi", a: "2.0", b: "1!1.0", cmp: -1},
+		{purl_type: "deb", a: "1.0~rc1", b: "1.0", cmp: -1},
+		{purl_type: "deb", a: "1.0", b: "1.0-1", cmp: -1},
+		{purl_type: "deb", a: "2.30-1", b: "2.4-1", cmp: 1},
+		{purl_type: "deb", a: "1:1.0", b: "2.0", cmp: 1},
+		{purl_type: "deb", a: "1.0a", b: "1.0+", cmp: -1},
+		{purl_type: "maven", a: "1.0", b: "1.0.0", cmp: 0},
+		{purl_type: "maven", a: "1-alpha", b: "1-beta", cmp: -1},
+		{purl_type: "maven", a: "1.0-SNAPSHOT", b: "1.0", cmp: -1},
+		{purl_type: "maven", a: "1.0-rc1", b: "1.0-cr1", cmp: 0},
+		{purl_type: "maven", a: "1.0", b: "1.0-sp1", cmp: -1},
+		{purl_type: "maven", a: "1.0.final", b: "1.0", cmp: 0},
+		{purl_type: "maven", a: "2.9.10", b: "2.10.0", cmp: -1},
+		{purl_type: "rpm", a: "1.0~rc1", b: "1.0", cmp: -1},
+		{purl_type: "rpm", a: "1.0^git1", b: "1.0", cmp: 1},
+		{purl_type: "rpm", a: "1.0a", b: "1.0.1", cmp: -1},
+		{purl_type: "rpm", a: "1:1.0-1", b: "2.0-1", cmp: 1},
+		{purl_type: "rpm", a: "1.0-1.el8", b: "1.0-2.el8", cmp: -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.purl_type+" "+test.a+" "+test.b, func(t *testing.T) {
+			cmp, err := versioning.Compare(test.purl_type, test.a, test.b)
+			assert.NilError(t, err, "Compare versions")
+			assert.Equal(t, cmp, test.cmp)
+
+			cmp, err = versioning.Compare(test.purl_type, test.b, test.a)
+			assert.NilError(t, err, "Compare versions reversed")
+			assert.Equal(t, cmp, -test.cmp)
+		})
+	}
+}
+
+func TestVersionClassify(t *testing.T) {
+	tests := []struct {
+		purl_type string
+		current   string
+		latest    string
+		class     versioning.UpgradeClass
+	}{
+		{purl_type: "npm", current: "1.2.3", latest: "2.0.0", class: versioning.UpgradeMajor},
+		{purl_type: "npm", current: "1.2.3", latest: "1.3.0", class: versioning.UpgradeMinor},
+		{purl_type: "npm", current: "1.2.3", latest: "1.2.4", class: versioning.UpgradePatch},
+		{purl_type: "npm", current: "1.2.3", latest: "1.3.0-rc.1", class: versioning.UpgradePrerelease},
+		{purl_type: "npm", current: "1.2.3", latest: "1.2.3", class: versioning.UpgradeNone},
+		{purl_type: "pypi", current: "2.25.1", latest: "2.24.0", class: versioning.UpgradeDowngrade},
+		{purl_type: "maven", current: "2.9.10", latest: "2.12.3", class: versioning.UpgradeMinor},
+		{purl_type: "deb", current: "1.1.1k-1", latest: "1.1.1n-0+deb11u1", class: versioning.UpgradePatch},
+	}
+
+	for _, test := range tests {
+		t.Run(test.purl_type+" "+test.current+" "+test.latest, func(t *testing.T) {
+			_, class, err := versioning.Classify(test.purl_type, test.current, test.latest)
+			assert.NilError(t, err, "Classify upgrade")
+			assert.Equal(t, class, test.class)
+		})
+	}
+
+	_, class, err := versioning.Classify("generic", "1.0", "2.0")
+	assert.ErrorContains(t, err, "unsupported purl type")
+	assert.Equal(t, class, versioning.UpgradeUnknown)
+}
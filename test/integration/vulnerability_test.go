@@ -0,0 +1,166 @@
+package integration
+
+import (
+	"context"
+	"deptrack/client"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestVulnerabilityDecode(t *testing.T) {
+	tests := []struct {
+		fixture        string
+		vuln_id        string
+		cwes           []int
+		cvss_v3_vector string
+		published      time.Time
+		aliases        []string
+		references     int
+		epss           float64
+		ranges         int
+	}{
+		{
+			fixture:        "test-fixtures/vulnerability/nvd.json",
+			vuln_id:        "CVE-2021-44228",
+			cwes:           []int{20, 400, 502},
+			cvss_v3_vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H",
+			published:      time.Date(2021, 12, 10, 18, 5, 0, 0, time.UTC),
+			aliases:        []string{"GHSA-jfh8-c2jp-5v3q"},
+			references:     2,
+			epss:           0.97565,
+			ranges:         2,
+		},
+		{
+			fixture:        "test-fixtures/vulnerability/legacy.json",
+			vuln_id:        "CVE-2019-10744",
+			cwes:           []int{20},
+			cvss_v3_vector: "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:H/A:H",
+			published:      time.Date(2019, 7, 16, 0, 0, 0, 0, time.UTC),
+			references:     1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			raw, err := ioutil.ReadFile(test.fixture)
+			assert.NilError(t, err, "Read fixture")
+
+			var vulnerability client.Vulnraibility
+			assert.NilError(t, json.Unmarshal(raw, &vulnerability), "Decode vulnerability")
+
+			assert.Equal(t, vulnerability.VulnId, test.vuln_id)
+			var cwes []int
+			for _, cwe := range vulnerability.AllCwes() {
+				cwes = append(cwes, cwe.CweId)
+			}
+			assert.DeepEqual(t, cwes, test.cwes)
+			assert.Equal(t, vulnerability.CvssV3Vector, test.cvss_v3_vector)
+			assert.Assert(t, vulnerability.PublishedTime().Equal(test.published), vulnerability.PublishedTime())
+			assert.DeepEqual(t, vulnerability.AliasIds(), test.aliases)
+			assert.Equal(t, len(vulnerability.ReferenceUrls()), test.references)
+			assert.Equal(t, vulnerability.EpssScore, test.epss)
+			assert.Equal(t, len(vulnerability.VulnerableSoftware), test.ranges)
+		})
+	}
+}
+
+func TestBlastRadius(t *testing.T) {
+	finding := func(component string, vuln_id string, state string) client.Finding {
+		return client.Finding{
+			Component:     client.FindingComponent{UUID: component, Purl: "pkg:npm/lodash@4.17.15"},
+			Vulnerability: client.FindingVulnerability{VulnId: vuln_id, Source: client.SourceNVD},
+			Analysis:      client.FindingAnalysis{State: state},
+		}
+	}
+	findings := map[string]client.FindingList{
+		"p1": {finding("c1", "CVE-2021-23337", "EXPLOITABLE"), finding("c2", "CVE-2020-8203", "")},
+		"p2": {finding("c3", "CVE-2021-23337", "")},
+	}
+
+	tests := []struct {
+		name    string
+		failing string
+		entries []client.BlastRadiusEntry
+	}{
+		{
+			name: "all projects",
+			entries: []client.BlastRadiusEntry{
+				{Project: "p1", ProjectName: "shop", ProjectVersion: "1.0", Active: true, Component: "c1",
+					Purl: "pkg:npm/lodash@4.17.15", State: client.AnalysisStateExploitable},
+				{Project: "p2", ProjectName: "billing", Component: "c3", Purl: "pkg:npm/lodash@4.17.15", State: client.AnalysisStateNotSet},
+			},
+		},
+		{
+			name:    "project error",
+			failing: "p2",
+			entries: []client.BlastRadiusEntry{
+				{Project: "p1", ProjectName: "shop", ProjectVersion: "1.0", Active: true, Component: "c1",
+					Purl: "pkg:npm/lodash@4.17.15", State: client.AnalysisStateExploitable},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewFakeDepClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v1/vulnerability/source/NVD/vuln/CVE-2021-23337":
+					json.NewEncoder(w).Encode(client.Vulnraibility{Source: client.SourceNVD, VulnId: "CVE-2021-23337", Severity: "HIGH"})
+				case "/api/v1/vulnerability/source/NVD/vuln/CVE-2021-23337/projects":
+					json.NewEncoder(w).Encode(client.AffectedProjectList{
+						{UUID: "p1", Name: "shop", Version: "1.0", Active: true},
+						{UUID: "p2", Name: "billing"},
+					})
+				case "/api/v1/finding/project/p1", "/api/v1/finding/project/p2":
+					project_uuid := r.URL.Path[len("/api/v1/finding/project/"):]
+					if project_uuid == test.failing {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					json.NewEncoder(w).Encode(findings[project_uuid])
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			blast_radius, err := c.BlastRadiusContext(context.Background(), client.SourceNVD, "CVE-2021-23337", client.DefaultWalkOptions)
+			if test.failing != "" {
+				var walk_err *client.WalkError
+				assert.Assert(t, errors.As(err, &walk_err), err)
+				assert.Equal(t, len(walk_err.Errors), 1)
+			} else {
+				assert.NilError(t, err, "Blast radius")
+			}
+			assert.Equal(t, blast_radius.Vulnerability.Severity, "HIGH")
+			assert.Equal(t, blast_radius.Projects, 2)
+			assert.DeepEqual(t, blast_radius.Entries, test.entries)
+		})
+	}
+}
+
+func TestVulnraibilityListSuppressed(t *testing.T) {
+	var suppressed []string
+	c := NewFakeDepClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/component/identity":
+			json.NewEncoder(w).Encode(client.ComponentList{{UUID: "c1", Name: "lodash"}})
+		case "/api/v1/vulnerability/component/c1":
+			suppressed = append(suppressed, r.URL.Query().Get("suppressed"))
+			json.NewEncoder(w).Encode(client.VulnraibilityList{{VulnId: "CVE-2021-23337", Source: client.SourceNVD}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	vulnraibility_list, err := c.GetVulnraibilityListContext(context.Background(), "pkg:npm/lodash@4.17.15")
+	assert.NilError(t, err, "Vulnerability list")
+	assert.Equal(t, len(vulnraibility_list), 1)
+	// Suppressed vulnerabilities are not requested
+	assert.DeepEqual(t, suppressed, []string{""})
+}
@@ -0,0 +1,120 @@
+package integration
+
+import (
+	"context"
+	"deptrack/client"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestWaitforSbomFinishUpload(t *testing.T) {
+	tests := []struct {
+		name       string
+		processing int32
+		status     int
+		timeout    time.Duration
+		attempts   uint
+		err        error
+	}{
+		{name: "finished", processing: 3, attempts: 4},
+		{name: "timeout", processing: -1, timeout: 50 * time.Millisecond, err: client.ErrWaitTimeout},
+		{name: "server error", status: http.StatusInternalServerError, attempts: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var polls int32
+			c := NewFakeDepClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/bom/token/token" {
+					http.NotFound(w, r)
+					return
+				}
+				if test.status != 0 {
+					w.WriteHeader(test.status)
+					return
+				}
+				poll := atomic.AddInt32(&polls, 1)
+				if test.processing < 0 || poll <= test.processing {
+					w.Write([]byte(`{"processing":true}`))
+					return
+				}
+				w.Write([]byte(`{"processing":false}`))
+			})
+
+			var results []client.SbomPollResult
+			opts := client.WaitOptions{
+				Timeout:         test.timeout,
+				InitialInterval: time.Millisecond,
+				MaxInterval:     5 * time.Millisecond,
+				OnPoll: func(result client.SbomPollResult) {
+					results = append(results, result)
+				},
+			}
+			done, err := c.WaitforSbomFinishUploadWithOptions(context.Background(), "token", opts)
+
+			switch {
+			case test.status != 0:
+				var api_err *client.APIError
+				assert.Assert(t, errors.As(err, &api_err), err)
+				assert.Equal(t, api_err.StatusCode, test.status)
+			case test.err != nil:
+				assert.Assert(t, errors.Is(err, test.err), err)
+			default:
+				assert.NilError(t, err, "Wait")
+			}
+			assert.Equal(t, done, err == nil)
+			if test.attempts != 0 {
+				assert.Equal(t, uint(len(results)), test.attempts)
+				assert.Equal(t, results[len(results)-1].Attempt, test.attempts)
+			}
+		})
+	}
+}
+
+func TestWaitForServer(t *testing.T) {
+	tests := []struct {
+		name        string
+		unavailable int32
+		status      int
+		timeout     time.Duration
+		ready       bool
+		polls       int32
+	}{
+		{name: "starting", unavailable: 2, status: http.StatusServiceUnavailable, timeout: time.Second, ready: true, polls: 3},
+		{name: "down", unavailable: -1, status: http.StatusServiceUnavailable, timeout: 50 * time.Millisecond},
+		{name: "wrong path", unavailable: -1, status: http.StatusNotFound, timeout: time.Second, polls: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var polls int32
+			c := NewFakeDepClient(t, func(w http.ResponseWriter, r *http.Request) {
+				poll := atomic.AddInt32(&polls, 1)
+				if r.URL.Path != "/api/version" || test.unavailable < 0 || poll <= test.unavailable {
+					w.WriteHeader(test.status)
+					return
+				}
+				w.Write([]byte(`{"version":"4.11.0"}`))
+			})
+
+			opts := client.WaitOptions{Timeout: test.timeout, InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+			err := c.WaitForServerContext(context.Background(), opts)
+			if test.ready {
+				assert.NilError(t, err, "Wait for server")
+				assert.Equal(t, atomic.LoadInt32(&polls), test.polls)
+				return
+			}
+			assert.Assert(t, err != nil, "Down server ready")
+			// A client error is returned without a retry
+			if test.polls != 0 {
+				assert.Equal(t, client.StatusCode(err), test.status)
+				assert.Equal(t, atomic.LoadInt32(&polls), test.polls)
+			}
+		})
+	}
+}
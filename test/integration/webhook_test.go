@@ -0,0 +1,56 @@
+package integration
+
+import (
+	"context"
+	"deptrack/webhook"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestWebhookReceiver(t *testing.T) {
+	var bom_subject *webhook.BomSubject
+	var vulnerability_subject *webhook.NewVulnerabilitySubject
+
+	receiver := webhook.NewReceiver()
+	receiver.OnBomProcessed(func(ctx context.Context, n *webhook.Notification, subject *webhook.BomSubject) error {
+		bom_subject = subject
+		return nil
+	})
+	receiver.OnNewVulnerability(func(ctx context.Context, n *webhook.Notification, subject *webhook.NewVulnerabilitySubject) error {
+		vulnerability_subject = subject
+		return nil
+	})
+
+	tests := []struct {
+		fixture string
+		status  int
+	}{
+		{fixture: "test-fixtures/webhook/bom_processed.json", status: http.StatusNoContent},
+		{fixture: "test-fixtures/webhook/new_vulnerability.json", status: http.StatusNoContent},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			body, err := os.Open(test.fixture)
+			assert.NilError(t, err, "Open fixture")
+			defer body.Close()
+
+			recorder := httptest.NewRecorder()
+			receiver.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", body))
+			assert.Equal(t, recorder.Code, test.status)
+		})
+	}
+
+	assert.Assert(t, bom_subject != nil, "BOM_PROCESSED not dispatched")
+	assert.Equal(t, bom_subject.Project.Name, "python")
+	assert.Equal(t, bom_subject.Token, "c5d7a2c2-41a6-4c58-8d62-7b1d6b1cf6ce")
+
+	assert.Assert(t, vulnerability_subject != nil, "NEW_VULNERABILITY not dispatched")
+	assert.Equal(t, vulnerability_subject.Vulnerability.VulnId, "CVE-2021-21300")
+	assert.Equal(t, vulnerability_subject.Vulnerability.Cwe.CweId, 59)
+	assert.Equal(t, len(vulnerability_subject.AffectedProjects), 1)
+}
@@ -0,0 +1,107 @@
+package versioning
+
+import (
+	"strings"
+)
+
+type dpkg struct{}
+
+// Dpkg orders Debian package versions [epoch:]upstream[-revision] like dpkg --compare-versions.
+var Dpkg Scheme = dpkg{}
+
+type dpkgVersion struct {
+	epoch    string
+	upstream string
+	revision string
+}
+
+func parseDpkg(v string) (*dpkgVersion, error) {
+	v = strings.TrimSpace(v)
+	parsed := dpkgVersion{epoch: "0", upstream: v}
+	if i := strings.IndexByte(parsed.upstream, ':'); i >= 0 {
+		parsed.epoch = parsed.upstream[:i]
+		parsed.upstream = parsed.upstream[i+1:]
+		if !isNumeric(parsed.epoch) {
+			return nil, &InvalidVersionError{Scheme: "dpkg", Version: v}
+		}
+	}
+	if i := strings.LastIndexByte(parsed.upstream, '-'); i >= 0 {
+		parsed.revision = parsed.upstream[i+1:]
+		parsed.upstream = parsed.upstream[:i]
+	}
+	if parsed.upstream == "" || !isDigit(parsed.upstream[0]) {
+		return nil, &InvalidVersionError{Scheme: "dpkg", Version: v}
+	}
+	return &parsed, nil
+}
+
+func (dpkg) Compare(a, b string) (int, error) {
+	va, err := parseDpkg(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseDpkg(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if cmp := compareNumeric(va.epoch, vb.epoch); cmp != 0 {
+		return cmp, nil
+	}
+	if cmp := verrevcmp(va.upstream, vb.upstream); cmp != 0 {
+		return cmp, nil
+	}
+	return verrevcmp(va.revision, vb.revision), nil
+}
+
+// dpkgOrder weights a character, ~ sorts before anything, even the end of the version, letters before other characters.
+func dpkgOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	c := s[i]
+	switch {
+	case isDigit(c):
+		return 0
+	case isAlpha(c):
+		return int(c)
+	case c == '~':
+		return -1
+	}
+	return int(c) + 256
+}
+
+// verrevcmp compares alternating non digit and digit parts as dpkg does.
+func verrevcmp(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			if cmp := dpkgOrder(a, i) - dpkgOrder(b, j); cmp != 0 {
+				return sign(cmp)
+			}
+			i++
+			j++
+		}
+
+		start_a := i
+		for i < len(a) && isDigit(a[i]) {
+			i++
+		}
+		start_b := j
+		for j < len(b) && isDigit(b[j]) {
+			j++
+		}
+		if cmp := compareNumeric(a[start_a:i], b[start_b:j]); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+func (dpkg) Release(v string) ([]int, bool, error) {
+	parsed, err := parseDpkg(v)
+	if err != nil {
+		return nil, false, err
+	}
+	return leadingRelease(parsed.upstream), strings.Contains(parsed.upstream, "~"), nil
+}
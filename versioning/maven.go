@@ -0,0 +1,259 @@
+package versioning
+
+import (
+	"strconv"
+	"strings"
+)
+
+type maven struct{}
+
+// Maven orders versions like maven-artifact ComparableVersion.
+var Maven Scheme = maven{}
+
+// mavenQualifiers are the well known qualifiers in ascending order, "" is the release.
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+var mavenAliases = map[string]string{
+	"ga":      "",
+	"final":   "",
+	"release": "",
+	"cr":      "rc",
+}
+
+var mavenReleaseIndex = strconv.Itoa(5)
+
+// mavenItem is a parsed version item, other is nil when comparing against a missing item.
+type mavenItem interface {
+	compare(other mavenItem) int
+	isNull() bool
+}
+
+type mavenInt string
+
+type mavenString string
+
+type mavenList []mavenItem
+
+func (i mavenInt) isNull() bool {
+	return strings.TrimLeft(string(i), "0") == ""
+}
+
+func (i mavenInt) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		if i.isNull() {
+			return 0
+		}
+		return 1
+	case mavenInt:
+		return compareNumeric(string(i), string(o))
+	}
+	return 1
+}
+
+func newMavenString(value string, followed_by_digit bool) mavenString {
+	if followed_by_digit && len(value) == 1 {
+		switch value {
+		case "a":
+			value = "alpha"
+		case "b":
+			value = "beta"
+		case "m":
+			value = "milestone"
+		}
+	}
+	if alias, ok := mavenAliases[value]; ok {
+		value = alias
+	}
+	return mavenString(value)
+}
+
+// comparable maps known qualifiers to their index, unknown ones sort after all of them, lexically.
+func (s mavenString) comparable() string {
+	for i, qualifier := range mavenQualifiers {
+		if string(s) == qualifier {
+			return strconv.Itoa(i)
+		}
+	}
+	return strconv.Itoa(len(mavenQualifiers)) + "-" + string(s)
+}
+
+func (s mavenString) isNull() bool {
+	return s.comparable() == mavenReleaseIndex
+}
+
+func (s mavenString) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		return strings.Compare(s.comparable(), mavenReleaseIndex)
+	case mavenString:
+		return strings.Compare(s.comparable(), o.comparable())
+	}
+	return -1
+}
+
+func (l mavenList) isNull() bool {
+	return len(l) == 0
+}
+
+func (l mavenList) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		if len(l) == 0 {
+			return 0
+		}
+		return l[0].compare(nil)
+	case mavenInt:
+		return -1
+	case mavenString:
+		return 1
+	case mavenList:
+		for i := 0; i < len(l) || i < len(o); i++ {
+			var left, right mavenItem
+			if i < len(l) {
+				left = l[i]
+			}
+			if i < len(o) {
+				right = o[i]
+			}
+
+			var cmp int
+			if left == nil {
+				if right != nil {
+					cmp = -right.compare(nil)
+				}
+			} else {
+				cmp = left.compare(right)
+			}
+			if cmp != 0 {
+				return cmp
+			}
+		}
+	}
+	return 0
+}
+
+// normalize drops trailing null items, 1.0.0 == 1 and 1-ga == 1.
+func (l *mavenList) normalize() {
+	for i := len(*l) - 1; i >= 0; i-- {
+		item := (*l)[i]
+		if item.isNull() {
+			*l = append((*l)[:i], (*l)[i+1:]...)
+		} else if _, ok := item.(mavenList); !ok {
+			break
+		}
+	}
+}
+
+// mavenNode lets sublists be appended to their parent before they are filled.
+type mavenNode struct {
+	items  mavenList
+	parent *mavenNode
+	index  int
+}
+
+func parseMavenItem(token string) mavenItem {
+	if isNumeric(token) {
+		return mavenInt(token)
+	}
+	return newMavenString(token, false)
+}
+
+func parseMaven(v string) mavenList {
+	v = strings.ToLower(strings.TrimSpace(v))
+
+	root := &mavenNode{}
+	node := root
+	var nodes []*mavenNode
+	nodes = append(nodes, root)
+	sublist := func() {
+		child := &mavenNode{parent: node, index: len(node.items)}
+		node.items = append(node.items, nil)
+		node = child
+		nodes = append(nodes, child)
+	}
+
+	is_digit := false
+	start := 0
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		switch {
+		case c == '.' || c == '-':
+			if i == start {
+				node.items = append(node.items, mavenInt("0"))
+			} else {
+				node.items = append(node.items, parseMavenItem(v[start:i]))
+			}
+			start = i + 1
+			if c == '-' {
+				sublist()
+			}
+		case isDigit(c):
+			if !is_digit && i > start {
+				node.items = append(node.items, newMavenString(v[start:i], true))
+				start = i
+				sublist()
+			}
+			is_digit = true
+		default:
+			if is_digit && i > start {
+				node.items = append(node.items, parseMavenItem(v[start:i]))
+				start = i
+				sublist()
+			}
+			is_digit = false
+		}
+	}
+	if len(v) > start {
+		node.items = append(node.items, parseMavenItem(v[start:]))
+	}
+
+	// Normalize and attach the innermost lists first, as ComparableVersion pops its stack
+	for i := len(nodes) - 1; i >= 0; i-- {
+		n := nodes[i]
+		n.items.normalize()
+		if n.parent != nil {
+			n.parent.items[n.index] = n.items
+		}
+	}
+	return root.items
+}
+
+func (maven) Compare(a, b string) (int, error) {
+	return sign(parseMaven(a).compare(parseMaven(b))), nil
+}
+
+func (maven) Release(v string) ([]int, bool, error) {
+	items := parseMaven(v)
+
+	var release []int
+	for _, item := range items {
+		number, ok := item.(mavenInt)
+		if !ok {
+			break
+		}
+		segment, err := strconv.Atoi(string(number))
+		if err != nil {
+			return nil, false, &InvalidVersionError{Scheme: "maven", Version: v}
+		}
+		release = append(release, segment)
+	}
+	return release, mavenPrerelease(items), nil
+}
+
+// mavenPrerelease reports whether any qualifier sorts before the release.
+func mavenPrerelease(items mavenList) bool {
+	for _, item := range items {
+		switch i := item.(type) {
+		case mavenString:
+			if i.compare(nil) < 0 {
+				return true
+			}
+		case mavenList:
+			if mavenPrerelease(i) {
+				return true
+			}
+		}
+	}
+	return false
+}
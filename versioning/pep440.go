@@ -0,0 +1,159 @@
+package versioning
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var pep440Regexp = regexp.MustCompile(`^v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(alpha|a|beta|b|preview|pre|c|rc)[-_.]?(\d+)?)?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+const (
+	pep440DevOnly = -1
+	pep440Alpha   = 0
+	pep440Beta    = 1
+	pep440Rc      = 2
+	pep440Final   = 3
+)
+
+type pep440 struct{}
+
+// Pep440 orders python package versions as specified by PEP 440, including the accepted alternate spellings.
+var Pep440 Scheme = pep440{}
+
+type pep440Version struct {
+	epoch    int
+	release  []int
+	pre      int
+	pre_num  int
+	post     int
+	dev      int
+	is_dev   bool
+	local    []string
+	has_post bool
+}
+
+func parsePep440(v string) (*pep440Version, error) {
+	match := pep440Regexp.FindStringSubmatch(strings.ToLower(strings.TrimSpace(v)))
+	if match == nil {
+		return nil, &InvalidVersionError{Scheme: "pep440", Version: v}
+	}
+
+	parsed := pep440Version{pre: pep440Final, post: -1, dev: math.MaxInt32}
+	parsed.epoch = atoi(match[1])
+	for _, number := range strings.Split(match[2], ".") {
+		parsed.release = append(parsed.release, atoi(number))
+	}
+	// Trailing zeros do not change the release, 1.0 == 1.0.0
+	for len(parsed.release) > 1 && parsed.release[len(parsed.release)-1] == 0 {
+		parsed.release = parsed.release[:len(parsed.release)-1]
+	}
+
+	switch match[3] {
+	case "alpha", "a":
+		parsed.pre = pep440Alpha
+	case "beta", "b":
+		parsed.pre = pep440Beta
+	case "preview", "pre", "c", "rc":
+		parsed.pre = pep440Rc
+	}
+	parsed.pre_num = atoi(match[4])
+
+	if match[5] != "" || match[6] != "" {
+		parsed.has_post = true
+		parsed.post = atoi(match[5] + match[7])
+	}
+	if match[8] != "" {
+		parsed.is_dev = true
+		parsed.dev = atoi(match[9])
+		// A dev release of a final release sorts before its pre-releases, 1.0.dev0 < 1.0a0
+		if parsed.pre == pep440Final && !parsed.has_post {
+			parsed.pre = pep440DevOnly
+		}
+	}
+	if match[10] != "" {
+		parsed.local = strings.FieldsFunc(match[10], func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+	return &parsed, nil
+}
+
+func atoi(s string) int {
+	number, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+func (pep440) Compare(a, b string) (int, error) {
+	va, err := parsePep440(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parsePep440(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if va.epoch != vb.epoch {
+		return sign(va.epoch - vb.epoch), nil
+	}
+	if cmp := compareInts(va.release, vb.release); cmp != 0 {
+		return cmp, nil
+	}
+	keys := [][2]int{{va.pre, vb.pre}, {va.pre_num, vb.pre_num}, {va.post, vb.post}, {va.dev, vb.dev}}
+	for _, key := range keys {
+		if key[0] != key[1] {
+			return sign(key[0] - key[1]), nil
+		}
+	}
+	return compareLocal(va.local, vb.local), nil
+}
+
+// compareInts orders integer sequences, missing segments count as zero.
+func compareInts(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if cmp := sign(segment(a, i) - segment(b, i)); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// compareLocal orders local version labels, numeric segments sort after alphanumeric ones.
+func compareLocal(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		a_numeric := isNumeric(a[i])
+		b_numeric := isNumeric(b[i])
+		switch {
+		case a_numeric && b_numeric:
+			if cmp := compareNumeric(a[i], b[i]); cmp != 0 {
+				return cmp
+			}
+		case a_numeric:
+			return 1
+		case b_numeric:
+			return -1
+		default:
+			if cmp := strings.Compare(a[i], b[i]); cmp != 0 {
+				return cmp
+			}
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+func (pep440) Release(v string) ([]int, bool, error) {
+	parsed, err := parsePep440(v)
+	if err != nil {
+		return nil, false, err
+	}
+	return parsed.release, parsed.pre != pep440Final || parsed.is_dev, nil
+}
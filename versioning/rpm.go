@@ -0,0 +1,170 @@
+package versioning
+
+import (
+	"strings"
+)
+
+type rpm struct{}
+
+// Rpm orders RPM [epoch:]version[-release] versions like rpmvercmp.
+var Rpm Scheme = rpm{}
+
+type rpmVersion struct {
+	epoch   string
+	version string
+	release string
+}
+
+func parseRpm(v string) (*rpmVersion, error) {
+	v = strings.TrimSpace(v)
+	parsed := rpmVersion{epoch: "0", version: v}
+	if i := strings.IndexByte(parsed.version, ':'); i >= 0 {
+		parsed.epoch = parsed.version[:i]
+		parsed.version = parsed.version[i+1:]
+		if !isNumeric(parsed.epoch) {
+			return nil, &InvalidVersionError{Scheme: "rpm", Version: v}
+		}
+	}
+	if i := strings.LastIndexByte(parsed.version, '-'); i >= 0 {
+		parsed.release = parsed.version[i+1:]
+		parsed.version = parsed.version[:i]
+	}
+	if parsed.version == "" {
+		return nil, &InvalidVersionError{Scheme: "rpm", Version: v}
+	}
+	return &parsed, nil
+}
+
+func (rpm) Compare(a, b string) (int, error) {
+	va, err := parseRpm(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseRpm(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if cmp := compareNumeric(va.epoch, vb.epoch); cmp != 0 {
+		return cmp, nil
+	}
+	if cmp := rpmvercmp(va.version, vb.version); cmp != 0 {
+		return cmp, nil
+	}
+	// Releases are only compared when both versions have one
+	if va.release == "" || vb.release == "" {
+		return 0, nil
+	}
+	return rpmvercmp(va.release, vb.release), nil
+}
+
+func isRpmSeparator(c byte) bool {
+	return !isDigit(c) && !isAlpha(c) && c != '~' && c != '^'
+}
+
+// rpmvercmp compares alphanumeric segments, ~ sorts before and ^ after the end of a version.
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && isRpmSeparator(a[i]) {
+			i++
+		}
+		for j < len(b) && isRpmSeparator(b[j]) {
+			j++
+		}
+
+		a_tilde := i < len(a) && a[i] == '~'
+		b_tilde := j < len(b) && b[j] == '~'
+		if a_tilde || b_tilde {
+			if !a_tilde {
+				return 1
+			}
+			if !b_tilde {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		a_caret := i < len(a) && a[i] == '^'
+		b_caret := j < len(b) && b[j] == '^'
+		if a_caret || b_caret {
+			if i >= len(a) {
+				return -1
+			}
+			if j >= len(b) {
+				return 1
+			}
+			if !a_caret {
+				return 1
+			}
+			if !b_caret {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		start_a, start_b := i, j
+		is_num := isDigit(a[i])
+		if is_num {
+			for i < len(a) && isDigit(a[i]) {
+				i++
+			}
+			for j < len(b) && isDigit(b[j]) {
+				j++
+			}
+		} else {
+			for i < len(a) && isAlpha(a[i]) {
+				i++
+			}
+			for j < len(b) && isAlpha(b[j]) {
+				j++
+			}
+		}
+
+		// Segments of different types, numeric ones are newer
+		if start_b == j {
+			if is_num {
+				return 1
+			}
+			return -1
+		}
+
+		var cmp int
+		if is_num {
+			cmp = compareNumeric(a[start_a:i], b[start_b:j])
+		} else {
+			cmp = strings.Compare(a[start_a:i], b[start_b:j])
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+
+	if i >= len(a) && j >= len(b) {
+		return 0
+	}
+	if i >= len(a) {
+		return -1
+	}
+	return 1
+}
+
+func (rpm) Release(v string) ([]int, bool, error) {
+	parsed, err := parseRpm(v)
+	if err != nil {
+		return nil, false, err
+	}
+	return leadingRelease(parsed.version), strings.Contains(parsed.version, "~"), nil
+}
@@ -0,0 +1,117 @@
+package versioning
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var semverRegexp = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+type semver struct{}
+
+// Semver orders semantic versions as used by npm, golang and cargo.
+// A leading v and missing minor or patch numbers are accepted, build metadata is ignored.
+var Semver Scheme = semver{}
+
+type semverVersion struct {
+	release    [3]string
+	prerelease []string
+}
+
+func parseSemver(v string) (*semverVersion, error) {
+	match := semverRegexp.FindStringSubmatch(strings.TrimSpace(v))
+	if match == nil {
+		return nil, &InvalidVersionError{Scheme: "semver", Version: v}
+	}
+
+	var parsed semverVersion
+	for i := range parsed.release {
+		parsed.release[i] = match[i+1]
+		if parsed.release[i] == "" {
+			parsed.release[i] = "0"
+		}
+	}
+	if match[4] != "" {
+		parsed.prerelease = strings.Split(match[4], ".")
+	}
+	return &parsed, nil
+}
+
+func (semver) Compare(a, b string) (int, error) {
+	va, err := parseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range va.release {
+		if cmp := compareNumeric(va.release[i], vb.release[i]); cmp != 0 {
+			return cmp, nil
+		}
+	}
+	return comparePrerelease(va.prerelease, vb.prerelease), nil
+}
+
+// comparePrerelease orders pre-release identifiers, a version without pre-release is the newer one.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		a_numeric := isNumeric(a[i])
+		b_numeric := isNumeric(b[i])
+		switch {
+		case a_numeric && b_numeric:
+			if cmp := compareNumeric(a[i], b[i]); cmp != 0 {
+				return cmp
+			}
+		case a_numeric:
+			return -1
+		case b_numeric:
+			return 1
+		default:
+			if cmp := strings.Compare(a[i], b[i]); cmp != 0 {
+				return cmp
+			}
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (semver) Release(v string) ([]int, bool, error) {
+	parsed, err := parseSemver(v)
+	if err != nil {
+		return nil, false, err
+	}
+
+	release := make([]int, len(parsed.release))
+	for i, number := range parsed.release {
+		release[i], err = strconv.Atoi(number)
+		if err != nil {
+			return nil, false, &InvalidVersionError{Scheme: "semver", Version: v}
+		}
+	}
+	return release, len(parsed.prerelease) != 0, nil
+}
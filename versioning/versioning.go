@@ -0,0 +1,183 @@
+/*
+Package versioning compares package versions using the ordering rules of their ecosystem,
+selected by purl type.
+*/
+package versioning
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Scheme orders the versions of one ecosystem.
+type Scheme interface {
+	// Compare returns -1, 0 or 1 as a is older, equal or newer than b.
+	Compare(a, b string) (int, error)
+	// Release returns the leading numeric release segments of v and whether v is a pre-release.
+	Release(v string) ([]int, bool, error)
+}
+
+var ErrUnsupportedType = errors.New("unsupported purl type")
+
+// InvalidVersionError is returned for versions not following the scheme syntax.
+type InvalidVersionError struct {
+	Scheme  string
+	Version string
+}
+
+func (e *InvalidVersionError) Error() string {
+	return fmt.Sprintf("invalid %s version %q", e.Scheme, e.Version)
+}
+
+var (
+	mu      sync.RWMutex
+	schemes = map[string]Scheme{
+		"npm":    Semver,
+		"golang": Semver,
+		"cargo":  Semver,
+		"pypi":   Pep440,
+		"deb":    Dpkg,
+		"maven":  Maven,
+		"rpm":    Rpm,
+	}
+)
+
+// Register sets the scheme used for purl_type, replacing any existing one.
+func Register(purl_type string, scheme Scheme) {
+	mu.Lock()
+	defer mu.Unlock()
+	schemes[strings.ToLower(purl_type)] = scheme
+}
+
+// ForType returns the scheme of purl_type.
+func ForType(purl_type string) (Scheme, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	scheme, ok := schemes[strings.ToLower(purl_type)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, purl_type)
+	}
+	return scheme, nil
+}
+
+// Compare orders a and b using the scheme of purl_type.
+func Compare(purl_type string, a string, b string) (int, error) {
+	scheme, err := ForType(purl_type)
+	if err != nil {
+		return 0, err
+	}
+	return scheme.Compare(a, b)
+}
+
+type UpgradeClass string
+
+const (
+	UpgradeNone       UpgradeClass = "none"
+	UpgradeMajor      UpgradeClass = "major"
+	UpgradeMinor      UpgradeClass = "minor"
+	UpgradePatch      UpgradeClass = "patch"
+	UpgradePrerelease UpgradeClass = "prerelease"
+	UpgradeDowngrade  UpgradeClass = "downgrade"
+	UpgradeUnknown    UpgradeClass = "unknown"
+)
+
+// Classify compares current with latest and classifies the upgrade by the first release segment that changed.
+// Upgrading to a pre-release is classified as UpgradePrerelease, a latest older than current as UpgradeDowngrade.
+func Classify(purl_type string, current string, latest string) (int, UpgradeClass, error) {
+	scheme, err := ForType(purl_type)
+	if err != nil {
+		return 0, UpgradeUnknown, err
+	}
+
+	cmp, err := scheme.Compare(current, latest)
+	if err != nil {
+		return 0, UpgradeUnknown, err
+	}
+	switch {
+	case cmp == 0:
+		return cmp, UpgradeNone, nil
+	case cmp > 0:
+		return cmp, UpgradeDowngrade, nil
+	}
+
+	current_release, _, err := scheme.Release(current)
+	if err != nil {
+		return cmp, UpgradeUnknown, err
+	}
+	latest_release, prerelease, err := scheme.Release(latest)
+	if err != nil {
+		return cmp, UpgradeUnknown, err
+	}
+	if prerelease {
+		return cmp, UpgradePrerelease, nil
+	}
+
+	classes := []UpgradeClass{UpgradeMajor, UpgradeMinor}
+	for i, class := range classes {
+		if segment(current_release, i) != segment(latest_release, i) {
+			return cmp, class, nil
+		}
+	}
+	return cmp, UpgradePatch, nil
+}
+
+func segment(release []int, i int) int {
+	if i < len(release) {
+		return release[i]
+	}
+	return 0
+}
+
+// sign normalizes a comparison result to -1, 0 or 1.
+func sign(cmp int) int {
+	switch {
+	case cmp < 0:
+		return -1
+	case cmp > 0:
+		return 1
+	}
+	return 0
+}
+
+// compareNumeric orders two digit strings of any length.
+func compareNumeric(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return sign(len(a) - len(b))
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// leadingRelease parses the leading dot separated numbers of v, stopping at the first non numeric segment.
+func leadingRelease(v string) []int {
+	var release []int
+	for _, part := range strings.Split(v, ".") {
+		end := 0
+		for end < len(part) && isDigit(part[end]) {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		number := 0
+		for _, c := range []byte(part[:end]) {
+			number = number*10 + int(c-'0')
+		}
+		release = append(release, number)
+		if end != len(part) {
+			break
+		}
+	}
+	return release
+}
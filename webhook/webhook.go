@@ -0,0 +1,206 @@
+/*
+Package webhook receives dependency track notifications sent by the Outbound Webhook publisher
+and dispatches them as typed events to registered handlers.
+*/
+package webhook
+
+import (
+	"context"
+	"deptrack/client"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const MaxNotificationSize = 10 << 20
+
+type Project struct {
+	UUID    string `json:"uuid,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	Purl    string `json:"purl,omitempty"`
+}
+
+type Component struct {
+	UUID    string `json:"uuid,omitempty"`
+	Group   string `json:"group,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	Purl    string `json:"purl,omitempty"`
+	Md5     string `json:"md5,omitempty"`
+	Sha1    string `json:"sha1,omitempty"`
+	Sha256  string `json:"sha256,omitempty"`
+}
+
+type Cwe struct {
+	CweId int    `json:"cweId,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+type Vulnerability struct {
+	UUID        string  `json:"uuid,omitempty"`
+	VulnId      string  `json:"vulnId,omitempty"`
+	Source      string  `json:"source,omitempty"`
+	Title       string  `json:"title,omitempty"`
+	Description string  `json:"description,omitempty"`
+	CvssV2      float64 `json:"cvssv2,omitempty"`
+	CvssV3      float64 `json:"cvssv3,omitempty"`
+	Severity    string  `json:"severity,omitempty"`
+	Cwe         *Cwe    `json:"cwe,omitempty"`
+}
+
+type Bom struct {
+	Content     string `json:"content,omitempty"`
+	Format      string `json:"format,omitempty"`
+	SpecVersion string `json:"specVersion,omitempty"`
+}
+
+type BomSubject struct {
+	Project Project `json:"project"`
+	Bom     Bom     `json:"bom"`
+	// Token is the upload token, only sent by newer servers
+	Token string `json:"token,omitempty"`
+}
+
+type NewVulnerabilitySubject struct {
+	Component        Component     `json:"component"`
+	Vulnerability    Vulnerability `json:"vulnerability"`
+	AffectedProjects []Project     `json:"affectedProjects,omitempty"`
+}
+
+type PolicyViolation struct {
+	UUID            string                 `json:"uuid,omitempty"`
+	Type            string                 `json:"type,omitempty"`
+	Timestamp       string                 `json:"timestamp,omitempty"`
+	PolicyCondition client.PolicyCondition `json:"policyCondition"`
+}
+
+type PolicyViolationSubject struct {
+	Project         Project         `json:"project"`
+	Component       Component       `json:"component"`
+	PolicyViolation PolicyViolation `json:"policyViolation"`
+}
+
+// Notification is a notification as sent by the webhook publisher, Subject depends on Group.
+type Notification struct {
+	Level     string          `json:"level"`
+	Scope     string          `json:"scope"`
+	Group     string          `json:"group"`
+	Timestamp string          `json:"timestamp"`
+	Title     string          `json:"title"`
+	Content   string          `json:"content"`
+	Subject   json.RawMessage `json:"subject,omitempty"`
+}
+
+type envelope struct {
+	Notification Notification `json:"notification"`
+}
+
+// DecodeSubject decodes the raw subject into dst.
+func (n *Notification) DecodeSubject(dst interface{}) error {
+	if len(n.Subject) == 0 {
+		return fmt.Errorf("notification %s has no subject", n.Group)
+	}
+	return json.Unmarshal(n.Subject, dst)
+}
+
+type Handler func(ctx context.Context, n *Notification) error
+
+// Receiver is an http.Handler dispatching notifications to the handlers registered for their group.
+type Receiver struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewReceiver() *Receiver {
+	return &Receiver{handlers: make(map[string][]Handler)}
+}
+
+// On registers handler for a notification group, handlers run in registration order.
+func (r *Receiver) On(group string, handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[group] = append(r.handlers[group], handler)
+}
+
+func (r *Receiver) OnBomProcessed(handler func(ctx context.Context, n *Notification, subject *BomSubject) error) {
+	r.On(client.NotificationGroupBomProcessed, func(ctx context.Context, n *Notification) error {
+		var subject BomSubject
+		if err := n.DecodeSubject(&subject); err != nil {
+			return err
+		}
+		return handler(ctx, n, &subject)
+	})
+}
+
+func (r *Receiver) OnNewVulnerability(handler func(ctx context.Context, n *Notification, subject *NewVulnerabilitySubject) error) {
+	r.On(client.NotificationGroupNewVulnerability, func(ctx context.Context, n *Notification) error {
+		var subject NewVulnerabilitySubject
+		if err := n.DecodeSubject(&subject); err != nil {
+			return err
+		}
+		return handler(ctx, n, &subject)
+	})
+}
+
+func (r *Receiver) OnPolicyViolation(handler func(ctx context.Context, n *Notification, subject *PolicyViolationSubject) error) {
+	r.On(client.NotificationGroupPolicyViolation, func(ctx context.Context, n *Notification) error {
+		var subject PolicyViolationSubject
+		if err := n.DecodeSubject(&subject); err != nil {
+			return err
+		}
+		return handler(ctx, n, &subject)
+	})
+}
+
+// Decode reads a webhook request body into its notification.
+func Decode(body io.Reader) (*Notification, error) {
+	var e envelope
+	if err := json.NewDecoder(io.LimitReader(body, MaxNotificationSize)).Decode(&e); err != nil {
+		return nil, err
+	}
+	return &e.Notification, nil
+}
+
+// Dispatch runs the handlers registered for the notification group, stopping on the first error.
+func (r *Receiver) Dispatch(ctx context.Context, n *Notification) error {
+	r.mu.RLock()
+	handlers := r.handlers[n.Group]
+	r.mu.RUnlock()
+
+	if len(handlers) == 0 {
+		log.Debugf("No webhook handler, Group: %s", n.Group)
+	}
+	for _, handler := range handlers {
+		if err := handler(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	n, err := Decode(req.Body)
+	if err != nil {
+		log.Debugf("Webhook decode error, Err: %+v", err)
+		http.Error(w, "invalid notification", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.Dispatch(req.Context(), n); err != nil {
+		log.Errorf("Webhook handler error, Group: %s Err: %+v", n.Group, err)
+		http.Error(w, "handler failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}